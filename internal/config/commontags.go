@@ -46,6 +46,30 @@ const (
 	LOG_MESSAGE_KEY  = "message"
 	LOG_ERROR_KEY    = "error"
 	LOG_METADATA_KEY = "metadata"
+	// LOG_TRUNCATED_KEY is the key added to a log record or group when part of it was truncated
+	LOG_TRUNCATED_KEY = "truncated"
+	// LOG_TRUNCATED_MARKER is the value (or suffix) used in place of truncated content
+	LOG_TRUNCATED_MARKER = "...[truncated]"
+)
+
+// attribute names for the Google Cloud Error Reporting events
+const (
+	ERROR_REPORTING_TYPE_KEY            = "@type"
+	ERROR_REPORTING_TYPE                = "type.googleapis.com/google.devtools.clouderrorreporting.v1beta1.ReportedErrorEvent"
+	ERROR_REPORTING_STACK_TRACE_KEY     = "stack_trace"
+	ERROR_REPORTING_SERVICE_CONTEXT_KEY = "serviceContext"
+	ERROR_REPORTING_CONTEXT_KEY         = "context"
+)
+
+// attribute names for the domain events
+const (
+	EVENT_NAME = "event.name"
+)
+
+// attribute names for the classification of the errors recorded in a span
+const (
+	ERROR_CODE      = "error.code"
+	ERROR_RETRYABLE = "error.retryable"
 )
 
 // exporter attributes
@@ -22,35 +22,156 @@
 
 package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
 
-import "github.com/caarlos0/env/v11"
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
 
-// withEnvironment allows for passing in a map of environment variables
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
+	"github.com/caarlos0/env/v11"
+)
+
+// WithEnvironment allows for passing in a map of environment variables
 // to be used instead of the actual environment. This is mostly for testing.
-func withEnvironment(environ map[string]string) Option {
+func WithEnvironment(environ map[string]string) Option {
 	return func(c *parseConfig) {
 		c.environment = environ
 	}
 }
 
+// WithHeaderProvider sets the provider of the headers which are refreshed while the OTLP exporters are running,
+// e.g. short-lived tokens. It takes precedence over the headers files (`OTEL_EXPORTER_OTLP_HEADERS_FILE`).
+//
+// It only applies to the Monitoring configuration.
+func WithHeaderProvider(provider credentials.HeaderProvider) Option {
+	return func(c *parseConfig) {
+		c.headerProvider = provider
+	}
+}
+
 type parseConfig struct {
-	environment map[string]string
+	environment    map[string]string
+	headerProvider credentials.HeaderProvider
 }
 
 // Option is a function that modifies the parseConfig.
-// The only implemented option is WithEnvironment, which is used for testing.
 type Option func(*parseConfig)
 
+// fileVariables are the secret variables which can be read from a file, whose path is given in the variable
+// with the `_FILE` suffix (e.g. `OTEL_EXPORTER_OTLP_ENDPOINT_FILE`).
+//
+// The headers are not in the list, because their files (`OTEL_EXPORTER_OTLP_HEADERS_FILE`) are read again
+// periodically by the exporters. The other `_FILE` variables, e.g. of the application, are left untouched.
+var fileVariables = []string{
+	"OTEL_EXPORTER_OTLP_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+	"OTEL_TRACES_SCRUB_HASH_KEY",
+}
+
+// fileVariableSuffix is the suffix of the variables which hold the path of the file to read a variable from.
+const fileVariableSuffix = "_FILE"
+
+// resolveFileVariables sets every secret variable which is not set, but has a `_FILE` variable,
+// to the content of the file (e.g. a mounted Kubernetes secret) without the surrounding white space.
+func resolveFileVariables(environment map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(environment))
+	for k, v := range environment {
+		resolved[k] = v
+	}
+
+	for _, name := range fileVariables {
+		k := name + fileVariableSuffix
+		path := environment[k]
+		if path == "" {
+			continue
+		}
+		if resolved[name] != "" {
+			// the variable takes precedence over its file
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s cannot be read: %w", k, err)
+		}
+		resolved[name] = strings.TrimSpace(string(content))
+	}
+
+	return resolved, nil
+}
+
+// parseInt parses the value of the variable as an integer not lower than the minimum,
+// or returns the default if the value is empty. The error wraps the given sentinel error.
+func parseInt(name, value string, def, minimum int, sentinel error) (int, error) {
+	if value == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err == nil && n >= minimum {
+		return n, nil
+	}
+
+	switch minimum {
+	case math.MinInt:
+		return 0, fmt.Errorf("%w: %s must be an integer, got %q", sentinel, name, value)
+	case 0:
+		return 0, fmt.Errorf("%w: %s must be a non-negative integer, got %q", sentinel, name, value)
+	case 1:
+		return 0, fmt.Errorf("%w: %s must be a positive integer, got %q", sentinel, name, value)
+	default:
+		return 0, fmt.Errorf("%w: %s must be an integer not lower than %d, got %q", sentinel, name, minimum, value)
+	}
+}
+
 // envParse is a wrapper around env.Parse that allows for passing in
 // an environment map for testing.
+//
+// If the `FLYR_CONFIG_FILE` variable points to a configuration file, its values
+// are used as the base environment and the actual environment variables override them.
+//
+// The secret variables can also be read from a file, whose path is given in the same variable
+// with the `_FILE` suffix (see resolveFileVariables).
 func envParse(v interface{}, opts ...Option) error {
-	if len(opts) == 0 {
-		return env.Parse(v)
+	return envParseWith(v, newParseConfig(opts))
+}
+
+// newParseConfig applies the options.
+func newParseConfig(opts []Option) parseConfig {
+	cfg := parseConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
 	}
+	return cfg
+}
+
+// envParseWith parses the environment of the given parseConfig into v.
+func envParseWith(v interface{}, cfg parseConfig) error {
 	// allow for optional passed in environment, used for testing
-	cfg := parseConfig{}
-	opts[0](&cfg)
-	options := env.Options{
-		Environment: cfg.environment,
+	environment := cfg.environment
+	if environment == nil {
+		environment = env.ToMap(os.Environ())
 	}
-	return env.ParseWithOptions(v, options)
+
+	if path := environment[ConfigFileEnv]; path != "" {
+		fileEnvironment, err := loadConfigFile(path)
+		if err != nil {
+			return err
+		}
+
+		for k, v := range environment {
+			fileEnvironment[k] = v
+		}
+		environment = fileEnvironment
+	}
+
+	environment, err := resolveFileVariables(environment)
+	if err != nil {
+		return err
+	}
+
+	return env.ParseWithOptions(v, env.Options{Environment: environment})
 }
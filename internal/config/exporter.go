@@ -0,0 +1,376 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
+)
+
+const (
+	// exporterEnvPrefix is the prefix of the environment variables shared by all signals.
+	exporterEnvPrefix = "OTEL_EXPORTER_OTLP_"
+	// tracesExporterEnvPrefix is the prefix of the environment variables specific to traces.
+	tracesExporterEnvPrefix = "OTEL_EXPORTER_OTLP_TRACES_"
+	// metricsExporterEnvPrefix is the prefix of the environment variables specific to metrics.
+	metricsExporterEnvPrefix = "OTEL_EXPORTER_OTLP_METRICS_"
+
+	// tracesURLPath is the path appended to the generic endpoint for OTLP/HTTP trace exports.
+	tracesURLPath = "/v1/traces"
+	// metricsURLPath is the path appended to the generic endpoint for OTLP/HTTP metric exports.
+	metricsURLPath = "/v1/metrics"
+)
+
+// ErrInvalidExporterConfig is returned when the OTLP exporter environment variables
+// contain an invalid value or an invalid combination of values.
+var ErrInvalidExporterConfig = errors.New("invalid OTLP exporter configuration")
+
+// ExporterSettings holds the raw OTLP exporter settings, as read from the environment.
+//
+// The same struct is used for the generic `OTEL_EXPORTER_OTLP_*` variables and for the
+// per-signal `OTEL_EXPORTER_OTLP_TRACES_*` and `OTEL_EXPORTER_OTLP_METRICS_*` variables.
+// Values are kept as strings so that they can be validated with a meaningful error
+// when the exporter is created, instead of failing while the environment is parsed.
+type ExporterSettings struct {
+	EndpointCfg          string `env:"ENDPOINT"`           // The target URL (or host:port for gRPC) of the OTLP receiver.
+	HeadersCfg           string `env:"HEADERS"`            // A comma separated list of key=value pairs; values are URL encoded.
+	HeadersFileCfg       string `env:"HEADERS_FILE"`       // The path to a file with headers in the same format, which is read again periodically.
+	TimeoutCfg           string `env:"TIMEOUT"`            // The maximum time in milliseconds the exporter waits for each batch export.
+	CompressionCfg       string `env:"COMPRESSION"`        // The compression used by the exporter; either "gzip" or "none".
+	InsecureCfg          string `env:"INSECURE"`           // Whether to disable the client transport security.
+	CertificateCfg       string `env:"CERTIFICATE"`        // The path to the PEM file with the trusted certificates of the server.
+	ClientCertificateCfg string `env:"CLIENT_CERTIFICATE"` // The path to the PEM file with the client certificate for mTLS.
+	ClientKeyCfg         string `env:"CLIENT_KEY"`         // The path to the PEM file with the client private key for mTLS.
+}
+
+// OTLPExporter is the resolved and validated configuration of the OTLP exporter of a single signal.
+//
+// Per-signal values take precedence over the generic ones. Zero values mean that the
+// exporter should use its own default.
+type OTLPExporter struct {
+	// Protocol is the OTLP transport protocol ("grpc" or "http/protobuf").
+	Protocol string
+	// Endpoint is the full URL (or host:port for gRPC) of the receiver.
+	Endpoint string
+	// Headers are sent with every export request.
+	Headers map[string]string
+	// HeadersFile is the path to the file with the headers which are read again periodically.
+	HeadersFile string
+	// HeaderProvider provides the headers which are refreshed while the exporter is running.
+	// They are sent with every export request and override the Headers with the same key.
+	HeaderProvider credentials.HeaderProvider
+	// Timeout is the maximum time the exporter waits for each export.
+	Timeout time.Duration
+	// Compression is either "gzip" or empty for no compression.
+	Compression string
+	// Insecure disables the client transport security.
+	Insecure bool
+	// TLS is the client TLS configuration, built from the certificate files.
+	TLS *tls.Config
+}
+
+// TracesExporter returns the resolved configuration of the OTLP Trace exporter.
+//
+// If both `OTEL_EXPORTER_OTLP_*` and `OTEL_EXPORTER_OTLP_TRACES_*` are present,
+// `OTEL_EXPORTER_OTLP_TRACES_*` takes higher precedence.
+//
+// It returns an ErrInvalidExporterConfig error if any of the values is invalid.
+func (d Monitoring) TracesExporter() (OTLPExporter, error) {
+	return resolveExporter(d.ExporterTracesProtocol(), d.ExporterCfg, d.ExporterTracesCfg, tracesExporterEnvPrefix, tracesURLPath, d.headerProvider)
+}
+
+// MetricsExporter returns the resolved configuration of the OTLP Metrics exporter.
+//
+// If both `OTEL_EXPORTER_OTLP_*` and `OTEL_EXPORTER_OTLP_METRICS_*` are present,
+// `OTEL_EXPORTER_OTLP_METRICS_*` takes higher precedence.
+//
+// It returns an ErrInvalidExporterConfig error if any of the values is invalid.
+func (d Monitoring) MetricsExporter() (OTLPExporter, error) {
+	return resolveExporter(d.ExporterMetricsProtocol(), d.ExporterCfg, d.ExporterMetricsCfg, metricsExporterEnvPrefix, metricsURLPath, d.headerProvider)
+}
+
+// setting is a single exporter value together with the name of the variable it was read from.
+type setting struct {
+	value string
+	name  string
+}
+
+// pick returns the per-signal value if present, otherwise the generic one.
+func pick(signal, generic string, signalPrefix, key string) setting {
+	if signal != "" {
+		return setting{value: signal, name: signalPrefix + key}
+	}
+	return setting{value: generic, name: exporterEnvPrefix + key}
+}
+
+// invalid wraps ErrInvalidExporterConfig with the given message.
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidExporterConfig, fmt.Sprintf(format, args...))
+}
+
+// resolveExporter merges the generic and per-signal settings and validates the result.
+//
+// The given header provider is used if not nil. Otherwise, if a headers file is configured,
+// the headers are read from the file.
+func resolveExporter(protocol string, generic, signal ExporterSettings, signalPrefix, urlPath string, provider credentials.HeaderProvider) (OTLPExporter, error) {
+	exporter := OTLPExporter{Protocol: protocol}
+	var err error
+
+	exporter.Endpoint, err = resolveEndpoint(protocol, generic.EndpointCfg, signal.EndpointCfg, signalPrefix, urlPath)
+	if err != nil {
+		return OTLPExporter{}, err
+	}
+
+	exporter.Headers, err = resolveHeaders(generic.HeadersCfg, signal.HeadersCfg, signalPrefix)
+	if err != nil {
+		return OTLPExporter{}, err
+	}
+
+	headersFile := pick(signal.HeadersFileCfg, generic.HeadersFileCfg, signalPrefix, "HEADERS_FILE")
+	exporter.HeadersFile = headersFile.value
+	exporter.HeaderProvider = provider
+	if provider == nil && headersFile.value != "" {
+		exporter.HeaderProvider = credentials.NewFileHeaderProvider(headersFile.value, credentials.DefaultRefreshInterval)
+		// fail early if the file cannot be read, since the provider only fails on the first read
+		if _, err := exporter.HeaderProvider.Headers(context.Background()); err != nil {
+			return OTLPExporter{}, invalid("%s cannot be read: %v", headersFile.name, err)
+		}
+	}
+
+	timeout := pick(signal.TimeoutCfg, generic.TimeoutCfg, signalPrefix, "TIMEOUT")
+	if timeout.value != "" {
+		ms, err := strconv.Atoi(timeout.value)
+		if err != nil || ms < 0 {
+			return OTLPExporter{}, invalid("%s must be a non-negative number of milliseconds, got %q", timeout.name, timeout.value)
+		}
+		exporter.Timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	compression := pick(signal.CompressionCfg, generic.CompressionCfg, signalPrefix, "COMPRESSION")
+	switch compression.value {
+	case "", "none":
+	case "gzip":
+		exporter.Compression = "gzip"
+	default:
+		return OTLPExporter{}, invalid("%s must be one of gzip or none, got %q", compression.name, compression.value)
+	}
+
+	insecure := pick(signal.InsecureCfg, generic.InsecureCfg, signalPrefix, "INSECURE")
+	if insecure.value != "" {
+		exporter.Insecure, err = strconv.ParseBool(insecure.value)
+		if err != nil {
+			return OTLPExporter{}, invalid("%s must be a boolean, got %q", insecure.name, insecure.value)
+		}
+	}
+	if strings.HasPrefix(exporter.Endpoint, "http://") {
+		exporter.Insecure = true
+	}
+
+	certificate := pick(signal.CertificateCfg, generic.CertificateCfg, signalPrefix, "CERTIFICATE")
+	clientCertificate := pick(signal.ClientCertificateCfg, generic.ClientCertificateCfg, signalPrefix, "CLIENT_CERTIFICATE")
+	clientKey := pick(signal.ClientKeyCfg, generic.ClientKeyCfg, signalPrefix, "CLIENT_KEY")
+
+	if exporter.Insecure && strings.HasPrefix(exporter.Endpoint, "https://") {
+		return OTLPExporter{}, invalid("%s is enabled but the endpoint %q uses https", insecure.name, exporter.Endpoint)
+	}
+	if exporter.Insecure && (certificate.value != "" || clientCertificate.value != "" || clientKey.value != "") {
+		return OTLPExporter{}, invalid("certificates cannot be used with an insecure exporter")
+	}
+	if (clientCertificate.value == "") != (clientKey.value == "") {
+		return OTLPExporter{}, invalid("%s and %s must be set together", clientCertificate.name, clientKey.name)
+	}
+
+	exporter.TLS, err = loadTLSConfig(certificate, clientCertificate, clientKey)
+	if err != nil {
+		return OTLPExporter{}, err
+	}
+
+	return exporter, nil
+}
+
+// resolveEndpoint returns the endpoint of the signal.
+//
+// A per-signal endpoint is used as is. The generic endpoint is used as the base URL
+// for OTLP/HTTP, so the signal path (e.g. `/v1/traces`) is appended to it.
+func resolveEndpoint(protocol, generic, signal, signalPrefix, urlPath string) (string, error) {
+	endpoint := pick(signal, generic, signalPrefix, "ENDPOINT")
+	if endpoint.value == "" {
+		return "", nil
+	}
+
+	if protocol == "grpc" && !strings.Contains(endpoint.value, "://") {
+		// gRPC accepts a plain host:port
+		if _, _, err := net.SplitHostPort(endpoint.value); err != nil {
+			return "", invalid("%s must be a URL or host:port, got %q", endpoint.name, endpoint.value)
+		}
+		return endpoint.value, nil
+	}
+
+	u, err := url.Parse(endpoint.value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", invalid("%s must be an http or https URL, got %q", endpoint.name, endpoint.value)
+	}
+
+	if signal == "" && protocol == "http/protobuf" {
+		u.Path = strings.TrimSuffix(u.Path, "/") + urlPath
+	}
+
+	return u.String(), nil
+}
+
+// resolveHeaders parses the generic and per-signal headers.
+// Per-signal headers override generic headers with the same key.
+func resolveHeaders(generic, signal, signalPrefix string) (map[string]string, error) {
+	headers, err := credentials.ParseHeaders(generic)
+	if err != nil {
+		return nil, invalid("%sHEADERS contains an %v", exporterEnvPrefix, err)
+	}
+
+	signalHeaders, err := credentials.ParseHeaders(signal)
+	if err != nil {
+		return nil, invalid("%sHEADERS contains an %v", signalPrefix, err)
+	}
+
+	for k, v := range signalHeaders {
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		headers[k] = v
+	}
+
+	return headers, nil
+}
+
+// loadTLSConfig builds the client TLS configuration from the certificate files.
+// It returns nil if none of the files is configured.
+func loadTLSConfig(certificate, clientCertificate, clientKey setting) (*tls.Config, error) {
+	if certificate.value == "" && clientCertificate.value == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if certificate.value != "" {
+		pem, err := os.ReadFile(certificate.value)
+		if err != nil {
+			return nil, invalid("%s cannot be read: %v", certificate.name, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, invalid("%s does not contain a valid PEM certificate", certificate.name)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCertificate.value != "" {
+		pair, err := tls.LoadX509KeyPair(clientCertificate.value, clientKey.value)
+		if err != nil {
+			return nil, invalid("%s and %s cannot be loaded: %v", clientCertificate.name, clientKey.name, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	return tlsConfig, nil
+}
+
+const (
+	// ExporterOTLP is the exporter type that sends the telemetry with the OTLP protocol.
+	ExporterOTLP = "otlp"
+	// ExporterConsole is the exporter type that writes the telemetry to the standard output.
+	ExporterConsole = "console"
+	// ExporterNone is the exporter type that disables the export of the telemetry.
+	ExporterNone = "none"
+)
+
+// TracesExporterTypes returns the exporters used for trace data, read from `OTEL_TRACES_EXPORTER`.
+//
+// See resolveExporterTypes for the accepted values.
+func (d Monitoring) TracesExporterTypes() ([]string, error) {
+	return d.resolveExporterTypes(d.TracesExporterTypesCfg, "OTEL_TRACES_EXPORTER")
+}
+
+// MetricsExporterTypes returns the exporters used for metric data, read from `OTEL_METRICS_EXPORTER`.
+//
+// See resolveExporterTypes for the accepted values.
+func (d Monitoring) MetricsExporterTypes() ([]string, error) {
+	return d.resolveExporterTypes(d.MetricsExporterTypesCfg, "OTEL_METRICS_EXPORTER")
+}
+
+// resolveExporterTypes validates the given exporter types.
+//
+// The accepted values are otlp, console, file and none, and more than one exporter can be given
+// (except for none). If no value is given, otlp is used.
+//
+// If `OTEL_SDK_DISABLED` is true, it always returns none.
+// If `OTEL_EXPORTER_OTLP_TEST` is true, otlp is replaced by console.
+func (d Monitoring) resolveExporterTypes(values []string, name string) ([]string, error) {
+	if d.SDKDisabled() {
+		return []string{ExporterNone}, nil
+	}
+
+	types := make([]string, 0, len(values))
+	seen := map[string]bool{}
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch value {
+		case "":
+			continue
+		case ExporterOTLP:
+			if d.IsTestExporter() {
+				value = ExporterConsole
+			}
+		case ExporterConsole, ExporterFile, ExporterNone:
+		default:
+			return nil, invalid("%s contains an unsupported exporter %q", name, value)
+		}
+
+		if !seen[value] {
+			seen[value] = true
+			types = append(types, value)
+		}
+	}
+
+	if len(types) == 0 {
+		if d.IsTestExporter() {
+			return []string{ExporterConsole}, nil
+		}
+		return []string{ExporterOTLP}, nil
+	}
+	if seen[ExporterNone] && len(types) > 1 {
+		return nil, invalid("%s cannot combine none with other exporters", name)
+	}
+
+	return types, nil
+}
@@ -0,0 +1,435 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracesAndMetricsExporter(t *testing.T) {
+	tests := []struct {
+		name            string
+		variables       map[string]string
+		expectedTraces  OTLPExporter
+		expectedMetrics OTLPExporter
+	}{
+		{
+			name:      "with empty values",
+			variables: map[string]string{},
+		},
+		{
+			name: "with generic http endpoint",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/protobuf",
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "https://collector:4318/",
+			},
+			expectedTraces:  OTLPExporter{Protocol: "http/protobuf", Endpoint: "https://collector:4318/v1/traces"},
+			expectedMetrics: OTLPExporter{Protocol: "http/protobuf", Endpoint: "https://collector:4318/v1/metrics"},
+		},
+		{
+			name: "with per-signal http endpoint",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":         "http/protobuf",
+				"OTEL_EXPORTER_OTLP_ENDPOINT":         "https://collector:4318",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT":  "https://traces:4318/custom",
+				"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": "https://metrics:4318",
+			},
+			expectedTraces:  OTLPExporter{Protocol: "http/protobuf", Endpoint: "https://traces:4318/custom"},
+			expectedMetrics: OTLPExporter{Protocol: "http/protobuf", Endpoint: "https://metrics:4318"},
+		},
+		{
+			name: "with grpc host and port",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "collector:4317",
+				"OTEL_EXPORTER_OTLP_INSECURE": "true",
+			},
+			expectedTraces:  OTLPExporter{Protocol: "grpc", Endpoint: "collector:4317", Insecure: true},
+			expectedMetrics: OTLPExporter{Protocol: "grpc", Endpoint: "collector:4317", Insecure: true},
+		},
+		{
+			name: "with http scheme implying insecure",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4317",
+			},
+			expectedTraces:  OTLPExporter{Protocol: "grpc", Endpoint: "http://collector:4317", Insecure: true},
+			expectedMetrics: OTLPExporter{Protocol: "grpc", Endpoint: "http://collector:4317", Insecure: true},
+		},
+		{
+			name: "with headers, timeout and compression",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":            "grpc",
+				"OTEL_EXPORTER_OTLP_HEADERS":             "api-key=secret, tenant=a%20b",
+				"OTEL_EXPORTER_OTLP_TRACES_HEADERS":      "api-key=traces",
+				"OTEL_EXPORTER_OTLP_TIMEOUT":             "2000",
+				"OTEL_EXPORTER_OTLP_METRICS_TIMEOUT":     "500",
+				"OTEL_EXPORTER_OTLP_COMPRESSION":         "gzip",
+				"OTEL_EXPORTER_OTLP_METRICS_COMPRESSION": "none",
+			},
+			expectedTraces: OTLPExporter{
+				Protocol:    "grpc",
+				Headers:     map[string]string{"api-key": "traces", "tenant": "a b"},
+				Timeout:     2 * time.Second,
+				Compression: "gzip",
+			},
+			expectedMetrics: OTLPExporter{
+				Protocol: "grpc",
+				Headers:  map[string]string{"api-key": "secret", "tenant": "a b"},
+				Timeout:  500 * time.Millisecond,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset the singleton state before each test
+			ResetMonitoringConfig()
+
+			cfg := NewMonitoringConfig(WithEnvironment(tt.variables))
+
+			traces, err := cfg.TracesExporter()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTraces, traces)
+
+			metrics, err := cfg.MetricsExporter()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMetrics, metrics)
+		})
+	}
+}
+
+func TestTracesExporterInvalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		variables     map[string]string
+		expectedError string
+	}{
+		{
+			name: "with invalid http endpoint",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/protobuf",
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "collector:4318",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_ENDPOINT must be an http or https URL",
+		},
+		{
+			name: "with invalid grpc endpoint",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":        "grpc",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "collector",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT must be a URL or host:port",
+		},
+		{
+			name: "with invalid header",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_HEADERS": "api-key",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_TRACES_HEADERS contains an invalid header",
+		},
+		{
+			name: "with negative timeout",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_TIMEOUT": "-1",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_TIMEOUT must be a non-negative number of milliseconds",
+		},
+		{
+			name: "with unsupported compression",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_COMPRESSION": "zstd",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_TRACES_COMPRESSION must be one of gzip or none",
+		},
+		{
+			name: "with invalid insecure",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_INSECURE": "maybe",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_INSECURE must be a boolean",
+		},
+		{
+			name: "with insecure and https endpoint",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "https://collector:4317",
+				"OTEL_EXPORTER_OTLP_INSECURE": "true",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_INSECURE is enabled but the endpoint",
+		},
+		{
+			name: "with insecure and certificate",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_INSECURE":           "true",
+				"OTEL_EXPORTER_OTLP_TRACES_CERTIFICATE": "/tmp/ca.pem",
+			},
+			expectedError: "certificates cannot be used with an insecure exporter",
+		},
+		{
+			name: "with client certificate without key",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": "/tmp/client.pem",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE and OTEL_EXPORTER_OTLP_CLIENT_KEY must be set together",
+		},
+		{
+			name: "with missing certificate file",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE": "/does/not/exist.pem",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_CERTIFICATE cannot be read",
+		},
+		{
+			name: "with missing headers file",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_HEADERS_FILE": "/does/not/exist",
+			},
+			expectedError: "OTEL_EXPORTER_OTLP_TRACES_HEADERS_FILE cannot be read",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset the singleton state before each test
+			ResetMonitoringConfig()
+
+			cfg := NewMonitoringConfig(WithEnvironment(tt.variables))
+
+			_, err := cfg.TracesExporter()
+			require.ErrorIs(t, err, ErrInvalidExporterConfig)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestExporterTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+
+	ResetMonitoringConfig()
+	cfg := NewMonitoringConfig(WithEnvironment(map[string]string{
+		"OTEL_EXPORTER_OTLP_CERTIFICATE":                "/does/not/exist.pem",
+		"OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE":        certFile,
+		"OTEL_EXPORTER_OTLP_METRICS_CLIENT_CERTIFICATE": certFile,
+		"OTEL_EXPORTER_OTLP_METRICS_CLIENT_KEY":         keyFile,
+	}))
+
+	metrics, err := cfg.MetricsExporter()
+	require.NoError(t, err)
+	require.NotNil(t, metrics.TLS)
+	assert.NotNil(t, metrics.TLS.RootCAs)
+	assert.Len(t, metrics.TLS.Certificates, 1)
+
+	// the traces exporter falls back to the invalid generic certificate
+	_, err = cfg.TracesExporter()
+	require.ErrorIs(t, err, ErrInvalidExporterConfig)
+}
+
+func TestExporterHeaderProvider(t *testing.T) {
+	ctx := context.Background()
+	headersFile := filepath.Join(t.TempDir(), "headers")
+	require.NoError(t, os.WriteFile(headersFile, []byte("api-key=from-file\n"), 0o600))
+
+	t.Run("reads the headers file", func(t *testing.T) {
+		cfg, err := ParseMonitoringConfig(WithEnvironment(map[string]string{
+			"OTEL_EXPORTER_OTLP_HEADERS":      "tenant=acme",
+			"OTEL_EXPORTER_OTLP_HEADERS_FILE": headersFile,
+		}))
+		require.NoError(t, err)
+
+		traces, err := cfg.TracesExporter()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"tenant": "acme"}, traces.Headers)
+		assert.Equal(t, headersFile, traces.HeadersFile)
+		require.NotNil(t, traces.HeaderProvider)
+
+		headers, err := traces.HeaderProvider.Headers(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"api-key": "from-file"}, headers)
+	})
+
+	t.Run("prefers the given header provider", func(t *testing.T) {
+		provider := credentials.HeaderProviderFunc(func(context.Context) (map[string]string, error) {
+			return map[string]string{"api-key": "from-provider"}, nil
+		})
+
+		cfg, err := ParseMonitoringConfig(
+			WithEnvironment(map[string]string{"OTEL_EXPORTER_OTLP_HEADERS_FILE": headersFile}),
+			WithHeaderProvider(provider),
+		)
+		require.NoError(t, err)
+
+		metrics, err := cfg.MetricsExporter()
+		require.NoError(t, err)
+		require.NotNil(t, metrics.HeaderProvider)
+
+		headers, err := metrics.HeaderProvider.Headers(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"api-key": "from-provider"}, headers)
+	})
+}
+
+// writeCertificate writes a self-signed certificate and its key to dir.
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "collector"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestExporterTypes(t *testing.T) {
+	tests := []struct {
+		name            string
+		variables       map[string]string
+		expectedTraces  []string
+		expectedMetrics []string
+	}{
+		{
+			name:            "with empty values",
+			variables:       map[string]string{},
+			expectedTraces:  []string{"otlp"},
+			expectedMetrics: []string{"otlp"},
+		},
+		{
+			name: "with per-signal exporters",
+			variables: map[string]string{
+				"OTEL_TRACES_EXPORTER":  "none",
+				"OTEL_METRICS_EXPORTER": "otlp, Console,otlp",
+			},
+			expectedTraces:  []string{"none"},
+			expectedMetrics: []string{"otlp", "console"},
+		},
+		{
+			name: "with file exporters",
+			variables: map[string]string{
+				"OTEL_TRACES_EXPORTER":  "file",
+				"OTEL_METRICS_EXPORTER": "otlp,file",
+			},
+			expectedTraces:  []string{"file"},
+			expectedMetrics: []string{"otlp", "file"},
+		},
+		{
+			name: "with test exporter",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_TEST": "true",
+				"OTEL_METRICS_EXPORTER":   "otlp,console",
+			},
+			expectedTraces:  []string{"console"},
+			expectedMetrics: []string{"console"},
+		},
+		{
+			name: "with sdk disabled",
+			variables: map[string]string{
+				"OTEL_SDK_DISABLED":     "true",
+				"OTEL_METRICS_EXPORTER": "otlp",
+			},
+			expectedTraces:  []string{"none"},
+			expectedMetrics: []string{"none"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset the singleton state before each test
+			ResetMonitoringConfig()
+
+			cfg := NewMonitoringConfig(WithEnvironment(tt.variables))
+
+			traces, err := cfg.TracesExporterTypes()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTraces, traces)
+
+			metrics, err := cfg.MetricsExporterTypes()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMetrics, metrics)
+		})
+	}
+}
+
+func TestExporterTypesInvalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expectedError string
+	}{
+		{
+			name:          "with unsupported exporter",
+			value:         "zipkin",
+			expectedError: `OTEL_TRACES_EXPORTER contains an unsupported exporter "zipkin"`,
+		},
+		{
+			name:          "with none and other exporters",
+			value:         "none,otlp",
+			expectedError: "OTEL_TRACES_EXPORTER cannot combine none with other exporters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset the singleton state before each test
+			ResetMonitoringConfig()
+
+			cfg := NewMonitoringConfig(WithEnvironment(map[string]string{"OTEL_TRACES_EXPORTER": tt.value}))
+
+			_, err := cfg.TracesExporterTypes()
+			require.ErrorIs(t, err, ErrInvalidExporterConfig)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,420 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv is the environment variable that holds the path of the configuration file.
+const ConfigFileEnv = "FLYR_CONFIG_FILE"
+
+// fileConfig describes the optional YAML (or JSON) configuration file.
+//
+// Every value maps to one of the environment variables read by the Monitoring and
+// Logger configurations. The precedence order is, from lowest to highest:
+//
+// 1. the defaults of the configuration structs
+//
+// 2. the values of the configuration file
+//
+// 3. the environment variables
+type fileConfig struct {
+	Service      string       `yaml:"service"`
+	SDKDisabled  *bool        `yaml:"sdk_disabled"`
+	Exporter     fileExporter `yaml:"exporter"`
+	FileExporter struct {
+		TracesPath  string `yaml:"traces_path"`
+		MetricsPath string `yaml:"metrics_path"`
+		MaxSize     *int   `yaml:"max_size_mb"`
+		MaxBackups  *int   `yaml:"max_backups"`
+	} `yaml:"file_exporter"`
+	Logger struct {
+		Level           string            `yaml:"level"`
+		PackageLevels   map[string]string `yaml:"package_levels"`
+		MaxValueLength  *int              `yaml:"max_value_length"`
+		MaxMetadataKeys *int              `yaml:"max_metadata_keys"`
+		MaxDepth        *int              `yaml:"max_depth"`
+		MaxRecordSize   *int              `yaml:"max_record_size"`
+		FlatMetadata    *bool             `yaml:"flat_metadata"`
+		Collision       string            `yaml:"metadata_collision"`
+		ErrorReporting  *bool             `yaml:"error_reporting"`
+		ResourceAttrs   *bool             `yaml:"resource_attributes"`
+	} `yaml:"logger"`
+	Tracer struct {
+		Exporters      []string           `yaml:"exporters"`
+		Exporter       fileExporter       `yaml:"exporter"`
+		Sampler        string             `yaml:"sampler"`
+		SamplingRatio  *float64           `yaml:"sampling_ratio"`
+		SamplingRules  []fileSamplingRule `yaml:"sampling_rules"`
+		BatchProcessor struct {
+			MaxQueueSize       *int   `yaml:"max_queue_size"`
+			MaxExportBatchSize *int   `yaml:"max_export_batch_size"`
+			ScheduleDelay      string `yaml:"schedule_delay"`
+			ExportTimeout      string `yaml:"export_timeout"`
+		} `yaml:"batch_processor"`
+		SpanLimits struct {
+			AttributeValueLength   *int `yaml:"attribute_value_length"`
+			AttributeCount         *int `yaml:"attribute_count"`
+			EventCount             *int `yaml:"event_count"`
+			LinkCount              *int `yaml:"link_count"`
+			AttributePerEventCount *int `yaml:"attribute_per_event_count"`
+			AttributePerLinkCount  *int `yaml:"attribute_per_link_count"`
+		} `yaml:"span_limits"`
+		TailSampling struct {
+			Enabled          *bool             `yaml:"enabled"`
+			DecisionWait     string            `yaml:"decision_wait"`
+			LatencyThreshold string            `yaml:"latency_threshold"`
+			Attributes       map[string]string `yaml:"attributes"`
+			Ratio            *float64          `yaml:"ratio"`
+			MaxTraces        *int              `yaml:"max_traces"`
+			MaxSpans         *int              `yaml:"max_spans"`
+		} `yaml:"tail_sampling"`
+		ScrubRules       []fileScrubRule `yaml:"scrub_rules"`
+		ViewerSpans      *int            `yaml:"viewer_spans"`
+		Propagators      []string        `yaml:"propagators"`
+		HttpClientTraces *bool           `yaml:"http_client_traces"`
+	} `yaml:"tracer"`
+	Meter struct {
+		Exporters []string     `yaml:"exporters"`
+		Exporter  fileExporter `yaml:"exporter"`
+		Interval  *float64     `yaml:"interval_seconds"`
+	} `yaml:"meter"`
+	Middleware struct {
+		ExcludedPaths []string `yaml:"excluded_paths"`
+	} `yaml:"middleware"`
+}
+
+// fileSamplingRule describes a sampling rule of the configuration file (see TracesSamplingRules).
+type fileSamplingRule struct {
+	Decision   string            `yaml:"decision"`
+	Name       string            `yaml:"name"`
+	Kind       string            `yaml:"kind"`
+	Attributes map[string]string `yaml:"attributes"`
+}
+
+// rule converts the sampling rule to the format of `OTEL_TRACES_SAMPLER_RULES`.
+func (r fileSamplingRule) rule() (string, error) {
+	conditions := []string{}
+	if r.Name != "" {
+		conditions = append(conditions, "name="+r.Name)
+	}
+	if r.Kind != "" {
+		conditions = append(conditions, "kind="+r.Kind)
+	}
+
+	keys := make([]string, 0, len(r.Attributes))
+	for k := range r.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		conditions = append(conditions, "attr."+k+"="+r.Attributes[k])
+	}
+
+	rule := r.Decision + ":" + strings.Join(conditions, ",")
+	if len(conditions) == 0 || strings.ContainsAny(strings.Join(conditions, ""), ",;") {
+		return "", fmt.Errorf("%w: invalid sampling rule %q", ErrInvalidSamplerConfig, rule)
+	}
+	return rule, nil
+}
+
+// fileScrubRule describes a scrubbing rule of the configuration file (see TracesScrubRules).
+type fileScrubRule struct {
+	Action string `yaml:"action"`
+	Key    string `yaml:"key"`
+	Value  string `yaml:"value"`
+}
+
+// rule converts the scrubbing rule to the format of `OTEL_TRACES_SCRUB_RULES`.
+func (r fileScrubRule) rule() (string, error) {
+	conditions := []string{}
+	if r.Key != "" {
+		conditions = append(conditions, "key="+r.Key)
+	}
+	if r.Value != "" {
+		conditions = append(conditions, "value="+r.Value)
+	}
+
+	rule := r.Action + ":" + strings.Join(conditions, ",")
+	if len(conditions) == 0 || strings.ContainsAny(r.Key, ",;") || strings.Contains(r.Value, ";") {
+		return "", fmt.Errorf("%w: invalid scrubbing rule %q", ErrInvalidScrubConfig, rule)
+	}
+	return rule, nil
+}
+
+// fileExporter describes the OTLP exporter settings of the configuration file.
+type fileExporter struct {
+	Test              *bool             `yaml:"test"`
+	Protocol          string            `yaml:"protocol"`
+	Endpoint          string            `yaml:"endpoint"`
+	Headers           map[string]string `yaml:"headers"`
+	HeadersFile       string            `yaml:"headers_file"`
+	Timeout           string            `yaml:"timeout"`
+	Compression       string            `yaml:"compression"`
+	Insecure          *bool             `yaml:"insecure"`
+	Certificate       string            `yaml:"certificate"`
+	ClientCertificate string            `yaml:"client_certificate"`
+	ClientKey         string            `yaml:"client_key"`
+}
+
+// loadConfigFile reads the configuration file and returns its values
+// keyed by the environment variable they correspond to.
+func loadConfigFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file %q: %w", path, err)
+	}
+
+	// JSON is valid YAML, so a single decoder handles both formats
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	file := fileConfig{}
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("config file %q: %w", path, err)
+	}
+
+	environment, err := file.environment()
+	if err != nil {
+		return nil, fmt.Errorf("config file %q: %w", path, err)
+	}
+
+	return environment, nil
+}
+
+// environment converts the file values to environment variables.
+// Values which are not present in the file are omitted.
+func (f fileConfig) environment() (map[string]string, error) {
+	environment := map[string]string{}
+	setString(environment, "OTEL_SERVICE_NAME", f.Service)
+	setBool(environment, "OTEL_SDK_DISABLED", f.SDKDisabled)
+	setList(environment, "OTEL_TRACES_EXPORTER", f.Tracer.Exporters)
+	setList(environment, "OTEL_METRICS_EXPORTER", f.Meter.Exporters)
+	setString(environment, "OTEL_EXPORTER_FILE_TRACES_PATH", f.FileExporter.TracesPath)
+	setString(environment, "OTEL_EXPORTER_FILE_METRICS_PATH", f.FileExporter.MetricsPath)
+	setInt(environment, "OTEL_EXPORTER_FILE_MAX_SIZE", f.FileExporter.MaxSize)
+	setInt(environment, "OTEL_EXPORTER_FILE_MAX_BACKUPS", f.FileExporter.MaxBackups)
+
+	if err := f.Exporter.environment(environment, exporterEnvPrefix); err != nil {
+		return nil, err
+	}
+	if err := f.Tracer.Exporter.environment(environment, tracesExporterEnvPrefix); err != nil {
+		return nil, err
+	}
+	if err := f.Meter.Exporter.environment(environment, metricsExporterEnvPrefix); err != nil {
+		return nil, err
+	}
+
+	setString(environment, "LOG_LEVEL", f.Logger.Level)
+	setMap(environment, "LOG_PACKAGE_LEVELS", f.Logger.PackageLevels)
+	setInt(environment, "LOG_MAX_VALUE_LENGTH", f.Logger.MaxValueLength)
+	setInt(environment, "LOG_MAX_METADATA_KEYS", f.Logger.MaxMetadataKeys)
+	setInt(environment, "LOG_MAX_DEPTH", f.Logger.MaxDepth)
+	setInt(environment, "LOG_MAX_RECORD_SIZE", f.Logger.MaxRecordSize)
+	setBool(environment, "LOG_METADATA_FLAT", f.Logger.FlatMetadata)
+	setString(environment, "LOG_METADATA_COLLISION", f.Logger.Collision)
+	setBool(environment, "LOG_ERROR_REPORTING", f.Logger.ErrorReporting)
+	setBool(environment, "LOG_RESOURCE_ATTRIBUTES", f.Logger.ResourceAttrs)
+
+	setBool(environment, "OTEL_ENABLE_HTTP_CLIENT_TRACES", f.Tracer.HttpClientTraces)
+	setList(environment, "OTEL_PROPAGATORS", f.Tracer.Propagators)
+	setString(environment, "OTEL_TRACES_SAMPLER", f.Tracer.Sampler)
+	setFloat(environment, "OTEL_TRACES_SAMPLER_ARG", f.Tracer.SamplingRatio)
+	if len(f.Tracer.SamplingRules) > 0 {
+		rules := make([]string, 0, len(f.Tracer.SamplingRules))
+		for _, r := range f.Tracer.SamplingRules {
+			rule, err := r.rule()
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+		}
+		environment["OTEL_TRACES_SAMPLER_RULES"] = strings.Join(rules, ";")
+	}
+
+	setInt(environment, "OTEL_BSP_MAX_QUEUE_SIZE", f.Tracer.BatchProcessor.MaxQueueSize)
+	setInt(environment, "OTEL_BSP_MAX_EXPORT_BATCH_SIZE", f.Tracer.BatchProcessor.MaxExportBatchSize)
+	if err := setMilliseconds(environment, "OTEL_BSP_SCHEDULE_DELAY", f.Tracer.BatchProcessor.ScheduleDelay); err != nil {
+		return nil, err
+	}
+	if err := setMilliseconds(environment, "OTEL_BSP_EXPORT_TIMEOUT", f.Tracer.BatchProcessor.ExportTimeout); err != nil {
+		return nil, err
+	}
+	setInt(environment, "OTEL_SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT", f.Tracer.SpanLimits.AttributeValueLength)
+	setInt(environment, "OTEL_SPAN_ATTRIBUTE_COUNT_LIMIT", f.Tracer.SpanLimits.AttributeCount)
+	setInt(environment, "OTEL_SPAN_EVENT_COUNT_LIMIT", f.Tracer.SpanLimits.EventCount)
+	setInt(environment, "OTEL_SPAN_LINK_COUNT_LIMIT", f.Tracer.SpanLimits.LinkCount)
+	setInt(environment, "OTEL_EVENT_ATTRIBUTE_COUNT_LIMIT", f.Tracer.SpanLimits.AttributePerEventCount)
+	setInt(environment, "OTEL_LINK_ATTRIBUTE_COUNT_LIMIT", f.Tracer.SpanLimits.AttributePerLinkCount)
+	setBool(environment, "OTEL_TRACES_TAIL_SAMPLING_ENABLED", f.Tracer.TailSampling.Enabled)
+	if err := setMilliseconds(environment, "OTEL_TRACES_TAIL_SAMPLING_DECISION_WAIT", f.Tracer.TailSampling.DecisionWait); err != nil {
+		return nil, err
+	}
+	if err := setMilliseconds(environment, "OTEL_TRACES_TAIL_SAMPLING_LATENCY_THRESHOLD", f.Tracer.TailSampling.LatencyThreshold); err != nil {
+		return nil, err
+	}
+	setMap(environment, "OTEL_TRACES_TAIL_SAMPLING_ATTRIBUTES", f.Tracer.TailSampling.Attributes)
+	setFloat(environment, "OTEL_TRACES_TAIL_SAMPLING_RATIO", f.Tracer.TailSampling.Ratio)
+	setInt(environment, "OTEL_TRACES_TAIL_SAMPLING_MAX_TRACES", f.Tracer.TailSampling.MaxTraces)
+	setInt(environment, "OTEL_TRACES_TAIL_SAMPLING_MAX_SPANS", f.Tracer.TailSampling.MaxSpans)
+	if len(f.Tracer.ScrubRules) > 0 {
+		rules := make([]string, 0, len(f.Tracer.ScrubRules))
+		for _, r := range f.Tracer.ScrubRules {
+			rule, err := r.rule()
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+		}
+		environment["OTEL_TRACES_SCRUB_RULES"] = strings.Join(rules, ";")
+	}
+	setInt(environment, "OTEL_TRACES_VIEWER_SPANS", f.Tracer.ViewerSpans)
+
+	setFloat(environment, "OTEL_METRICS_INTERVAL_SECONDS", f.Meter.Interval)
+	setList(environment, "OTEL_MIDDLEWARE_EXCLUDED_PATHS", f.Middleware.ExcludedPaths)
+
+	return environment, nil
+}
+
+// environment converts the exporter values to environment variables with the given prefix.
+func (e fileExporter) environment(environment map[string]string, prefix string) error {
+	// the test flag has no per-signal variant
+	if prefix == exporterEnvPrefix {
+		setBool(environment, "OTEL_EXPORTER_OTLP_TEST", e.Test)
+	} else if e.Test != nil {
+		return fmt.Errorf("%w: the test flag can only be set in the generic exporter", ErrInvalidExporterConfig)
+	}
+
+	setString(environment, prefix+"PROTOCOL", e.Protocol)
+	setString(environment, prefix+"ENDPOINT", e.Endpoint)
+	setString(environment, prefix+"HEADERS_FILE", e.HeadersFile)
+	setString(environment, prefix+"COMPRESSION", e.Compression)
+	setBool(environment, prefix+"INSECURE", e.Insecure)
+	setString(environment, prefix+"CERTIFICATE", e.Certificate)
+	setString(environment, prefix+"CLIENT_CERTIFICATE", e.ClientCertificate)
+	setString(environment, prefix+"CLIENT_KEY", e.ClientKey)
+
+	if e.Timeout != "" {
+		timeout, err := time.ParseDuration(e.Timeout)
+		if err != nil {
+			return fmt.Errorf("%w: invalid timeout %q", ErrInvalidExporterConfig, e.Timeout)
+		}
+		environment[prefix+"TIMEOUT"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+
+	if len(e.Headers) > 0 {
+		keys := make([]string, 0, len(e.Headers))
+		for k := range e.Headers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, k+"="+url.PathEscape(e.Headers[k]))
+		}
+		environment[prefix+"HEADERS"] = strings.Join(pairs, ",")
+	}
+
+	return nil
+}
+
+// setString sets the variable if the value is not empty.
+func setString(environment map[string]string, key, value string) {
+	if value != "" {
+		environment[key] = value
+	}
+}
+
+// setInt sets the variable if the value is present.
+func setInt(environment map[string]string, key string, value *int) {
+	if value != nil {
+		environment[key] = strconv.Itoa(*value)
+	}
+}
+
+// setMilliseconds sets the variable to the milliseconds of the duration, if the duration is not empty.
+func setMilliseconds(environment map[string]string, key, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%w: invalid duration %q of %s", ErrInvalidSpanProcessorConfig, value, key)
+	}
+	environment[key] = strconv.FormatInt(duration.Milliseconds(), 10)
+	return nil
+}
+
+// setBool sets the variable if the value is present.
+func setBool(environment map[string]string, key string, value *bool) {
+	if value != nil {
+		environment[key] = strconv.FormatBool(*value)
+	}
+}
+
+// setList sets the variable to the comma separated values, if any.
+func setList(environment map[string]string, key string, values []string) {
+	if len(values) > 0 {
+		environment[key] = strings.Join(values, ",")
+	}
+}
+
+// setFloat sets the variable if the value is present.
+func setFloat(environment map[string]string, key string, value *float64) {
+	if value != nil {
+		environment[key] = strconv.FormatFloat(*value, 'f', -1, 64)
+	}
+}
+
+// setMap sets the variable to the comma separated key=value pairs, if any.
+func setMap(environment map[string]string, key string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+values[k])
+	}
+	environment[key] = strings.Join(pairs, ",")
+}
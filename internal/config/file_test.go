@@ -0,0 +1,336 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yamlConfigFile = `
+service: file-service
+exporter:
+  protocol: grpc
+  endpoint: collector:4317
+  insecure: true
+  headers:
+    api-key: a b
+file_exporter:
+  traces_path: out/traces.jsonl
+  max_size_mb: 10
+logger:
+  level: debug
+  max_value_length: 128
+  flat_metadata: true
+tracer:
+  exporter:
+    timeout: 2s
+  http_client_traces: true
+  sampler: parentbased_always_on
+  sampling_rules:
+    - decision: always_off
+      attributes:
+        http.route: /healthz
+    - decision: "0.5"
+      name: GET /orders*
+      kind: server
+  batch_processor:
+    max_queue_size: 4096
+    schedule_delay: 2s
+  span_limits:
+    attribute_count: 64
+  tail_sampling:
+    enabled: true
+    decision_wait: 5s
+    attributes:
+      debug: "true"
+  scrub_rules:
+    - action: hash
+      key: user.id
+    - action: redact
+      value: '[^@\s]+@[^@\s]+'
+  viewer_spans: 500
+  propagators: [tracecontext, b3]
+meter:
+  exporter:
+    protocol: http/protobuf
+    endpoint: http://collector:4318
+  interval_seconds: 15
+middleware:
+  excluded_paths:
+    - /healthz
+    - /metrics
+`
+
+const jsonConfigFile = `{
+  "service": "json-service",
+  "logger": {"level": "warn"},
+  "meter": {"interval_seconds": 5}
+}`
+
+// writeConfigFile writes the content to a file in a temporary directory.
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestConfigFile(t *testing.T) {
+	t.Run("reads yaml file", func(t *testing.T) {
+		path := writeConfigFile(t, "config.yaml", yamlConfigFile)
+
+		cfg := Logger{}
+		require.NoError(t, envParse(&cfg, WithEnvironment(map[string]string{
+			ConfigFileEnv:                path,
+			"OTEL_TRACES_SCRUB_HASH_KEY": "secret",
+		})))
+
+		assert.Equal(t, "file-service", cfg.Service())
+		assert.Equal(t, "debug", cfg.LogLevel())
+		assert.Equal(t, 128, cfg.MaxValueLength())
+		assert.True(t, cfg.FlatMetadata())
+		assert.Equal(t, "prefix", cfg.MetadataCollisionPolicy())
+		assert.True(t, cfg.EnableHttpClientTraces())
+		assert.Equal(t, 15*time.Second, cfg.MetricsInterval())
+		assert.Equal(t, []string{"/healthz", "/metrics"}, cfg.MiddlewareExcludedPaths())
+
+		sampler, err := cfg.TracesSampler()
+		require.NoError(t, err)
+		assert.Equal(t, SamplerParentBasedAlwaysOn, sampler)
+
+		rules, err := cfg.TracesSamplingRules()
+		require.NoError(t, err)
+		assert.Equal(t, []SamplingRule{
+			{Attributes: map[string]string{"http.route": "/healthz"}, Ratio: 0},
+			{Name: "GET /orders*", Kind: "server", Ratio: 0.5},
+		}, rules)
+
+		processor, err := cfg.TracesBatchProcessor()
+		require.NoError(t, err)
+		assert.Equal(t, 4096, processor.MaxQueueSize)
+		assert.Equal(t, 2*time.Second, processor.ScheduleDelay)
+
+		limits, err := cfg.TracesSpanLimits()
+		require.NoError(t, err)
+		assert.Equal(t, 64, limits.AttributeCount)
+
+		tail, err := cfg.TracesTailSampling()
+		require.NoError(t, err)
+		assert.True(t, tail.Enabled)
+		assert.Equal(t, 5*time.Second, tail.DecisionWait)
+		assert.Equal(t, map[string]string{"debug": "true"}, tail.Attributes)
+
+		scrubRules, err := cfg.TracesScrubRules()
+		require.NoError(t, err)
+		require.Len(t, scrubRules, 2)
+		assert.Equal(t, ScrubRule{Action: ScrubHash, Key: "user.id", HashKey: []byte("secret")}, scrubRules[0])
+		assert.Equal(t, `[^@\s]+@[^@\s]+`, scrubRules[1].Value.String())
+
+		viewerSpans, err := cfg.TracesViewerSpans()
+		require.NoError(t, err)
+		assert.Equal(t, 500, viewerSpans)
+
+		propagators, err := cfg.Propagators()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tracecontext", "b3"}, propagators)
+
+		traces, err := cfg.TracesExporter()
+		require.NoError(t, err)
+		assert.Equal(t, OTLPExporter{
+			Protocol: "grpc",
+			Endpoint: "collector:4317",
+			Headers:  map[string]string{"api-key": "a b"},
+			Timeout:  2 * time.Second,
+			Insecure: true,
+		}, traces)
+
+		metrics, err := cfg.MetricsExporter()
+		require.NoError(t, err)
+		assert.Equal(t, "http/protobuf", metrics.Protocol)
+		assert.Equal(t, "http://collector:4318", metrics.Endpoint)
+
+		tracesFile, err := cfg.TracesFileExporter()
+		require.NoError(t, err)
+		assert.Equal(t, FileExporter{Path: "out/traces.jsonl", MaxSize: 10 << 20, MaxBackups: 5}, tracesFile)
+	})
+
+	t.Run("reads json file", func(t *testing.T) {
+		path := writeConfigFile(t, "config.json", jsonConfigFile)
+
+		cfg := Logger{}
+		require.NoError(t, envParse(&cfg, WithEnvironment(map[string]string{ConfigFileEnv: path})))
+
+		assert.Equal(t, "json-service", cfg.Service())
+		assert.Equal(t, "warn", cfg.LogLevel())
+		assert.Equal(t, 5*time.Second, cfg.MetricsInterval())
+	})
+
+	t.Run("environment overrides file", func(t *testing.T) {
+		path := writeConfigFile(t, "config.yaml", yamlConfigFile)
+
+		cfg := Logger{}
+		require.NoError(t, envParse(&cfg, WithEnvironment(map[string]string{
+			ConfigFileEnv:                       path,
+			"OTEL_SERVICE_NAME":                 "env-service",
+			"LOG_LEVEL":                         "error",
+			"OTEL_EXPORTER_OTLP_TRACES_TIMEOUT": "100",
+		})))
+
+		assert.Equal(t, "env-service", cfg.Service())
+		assert.Equal(t, "error", cfg.LogLevel())
+		assert.Equal(t, 128, cfg.MaxValueLength())
+
+		traces, err := cfg.TracesExporter()
+		require.NoError(t, err)
+		assert.Equal(t, 100*time.Millisecond, traces.Timeout)
+	})
+
+	t.Run("without file", func(t *testing.T) {
+		cfg := Logger{}
+		require.NoError(t, envParse(&cfg, WithEnvironment(map[string]string{})))
+
+		assert.Equal(t, "info", cfg.LogLevel())
+	})
+}
+
+func TestConfigFileInvalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "with unknown key",
+			content:       "logger:\n  colour: true\n",
+			expectedError: "field colour not found",
+		},
+		{
+			name:          "with wrong type",
+			content:       "logger:\n  max_depth: deep\n",
+			expectedError: "cannot unmarshal",
+		},
+		{
+			name:          "with invalid timeout",
+			content:       "exporter:\n  timeout: soon\n",
+			expectedError: "invalid timeout",
+		},
+		{
+			name:          "with per-signal test flag",
+			content:       "tracer:\n  exporter:\n    test: true\n",
+			expectedError: "the test flag can only be set in the generic exporter",
+		},
+		{
+			name:          "with sampling rule without conditions",
+			content:       "tracer:\n  sampling_rules:\n    - decision: always_on\n",
+			expectedError: "invalid sampling rule",
+		},
+		{
+			name:          "with invalid schedule delay",
+			content:       "tracer:\n  batch_processor:\n    schedule_delay: later\n",
+			expectedError: "invalid duration",
+		},
+		{
+			name:          "with scrubbing rule without conditions",
+			content:       "tracer:\n  scrub_rules:\n    - action: drop\n",
+			expectedError: "invalid scrubbing rule",
+		},
+		{
+			name:          "with invalid latency threshold",
+			content:       "tracer:\n  tail_sampling:\n    latency_threshold: slow\n",
+			expectedError: "invalid duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, "config.yaml", tt.content)
+
+			cfg := Monitoring{}
+			err := envParse(&cfg, WithEnvironment(map[string]string{ConfigFileEnv: path}))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+
+	t.Run("with missing file", func(t *testing.T) {
+		cfg := Monitoring{}
+		err := envParse(&cfg, WithEnvironment(map[string]string{ConfigFileEnv: "/does/not/exist.yaml"}))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestReparse(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "logger:\n  level: debug\n  package_levels:\n    github.com/acme/db: warn\nmeter:\n  interval_seconds: 30\n")
+	environment := map[string]string{
+		ConfigFileEnv:       path,
+		"OTEL_SERVICE_NAME": "explicit-service",
+		"LOG_MAX_DEPTH":     "3",
+	}
+
+	loggerCfg, err := ParseLoggerConfig(WithEnvironment(environment))
+	require.NoError(t, err)
+	monitoringCfg, err := ParseMonitoringConfig(WithEnvironment(environment))
+	require.NoError(t, err)
+
+	t.Run("picks up the changes of the file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("logger:\n  level: warn\nmeter:\n  interval_seconds: 10\n"), 0o600))
+
+		reloaded, err := loggerCfg.Reparse()
+		require.NoError(t, err)
+		assert.Equal(t, "warn", reloaded.LogLevel())
+		assert.Equal(t, 3, reloaded.MaxDepth())
+		assert.Equal(t, "explicit-service", reloaded.Service())
+
+		reloadedMonitoring, err := monitoringCfg.Reparse()
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, reloadedMonitoring.MetricsInterval())
+		assert.Equal(t, "explicit-service", reloadedMonitoring.Service())
+	})
+
+	t.Run("reverts the values removed from the file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("service: file-service\n"), 0o600))
+
+		reloaded, err := loggerCfg.Reparse()
+		require.NoError(t, err)
+		assert.Equal(t, "info", reloaded.LogLevel())
+		assert.Empty(t, reloaded.PackageLevels())
+		assert.Equal(t, time.Minute, reloaded.MetricsInterval())
+		// the environment still takes precedence over the file
+		assert.Equal(t, "explicit-service", reloaded.Service())
+	})
+
+	t.Run("with invalid file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("logger:\n  colour: true\n"), 0o600))
+
+		_, err := loggerCfg.Reparse()
+		require.ErrorContains(t, err, "field colour not found")
+	})
+}
@@ -0,0 +1,111 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+
+import (
+	"path/filepath"
+)
+
+const (
+	// ExporterFile is the exporter type that writes the telemetry to a file, as OTLP JSON lines.
+	ExporterFile = "file"
+
+	// defaultTracesFilePath is the default path of the file of the traces.
+	defaultTracesFilePath = "traces.jsonl"
+	// defaultMetricsFilePath is the default path of the file of the metrics.
+	defaultMetricsFilePath = "metrics.jsonl"
+	// defaultFileMaxSize is the default size in megabytes above which the file is rotated.
+	defaultFileMaxSize = 100
+	// defaultFileMaxBackups is the default number of rotated files which are kept.
+	defaultFileMaxBackups = 5
+)
+
+// FileExporterSettings holds the raw settings of the file exporter, read from the `OTEL_EXPORTER_FILE_*` environment variables.
+type FileExporterSettings struct {
+	TracesPathCfg  string `env:"TRACES_PATH"`  // The path of the file of the traces.
+	MetricsPathCfg string `env:"METRICS_PATH"` // The path of the file of the metrics.
+	MaxSizeCfg     string `env:"MAX_SIZE"`     // The size in megabytes above which the file is rotated.
+	MaxBackupsCfg  string `env:"MAX_BACKUPS"`  // The number of rotated files which are kept.
+}
+
+// FileExporter is the resolved configuration of the file exporter of a single signal.
+type FileExporter struct {
+	// Path is the path of the file, which is created with its directory if needed.
+	Path string
+	// MaxSize is the size in bytes above which the file is rotated.
+	MaxSize int64
+	// MaxBackups is the number of rotated files which are kept, as <path>.1 (the newest) to <path>.<MaxBackups>.
+	MaxBackups int
+}
+
+// TracesFileExporter returns the configuration of the file exporter of the traces, read from `OTEL_EXPORTER_FILE_*`.
+//
+// The traces are written to traces.jsonl by default, rotated above 100 megabytes, and 5 rotated files are kept.
+//
+// It returns an ErrInvalidExporterConfig error if any of the values is invalid.
+func (d Monitoring) TracesFileExporter() (FileExporter, error) {
+	return d.resolveFileExporter(d.FileExporterCfg.TracesPathCfg, defaultTracesFilePath)
+}
+
+// MetricsFileExporter returns the configuration of the file exporter of the metrics, read from `OTEL_EXPORTER_FILE_*`.
+//
+// The metrics are written to metrics.jsonl by default, rotated above 100 megabytes, and 5 rotated files are kept.
+//
+// It returns an ErrInvalidExporterConfig error if any of the values is invalid.
+func (d Monitoring) MetricsFileExporter() (FileExporter, error) {
+	return d.resolveFileExporter(d.FileExporterCfg.MetricsPathCfg, defaultMetricsFilePath)
+}
+
+// resolveFileExporter validates the settings of the file exporter of a signal.
+// The traces and the metrics cannot be written to the same file.
+func (d Monitoring) resolveFileExporter(path, defaultPath string) (FileExporter, error) {
+	settings := d.FileExporterCfg
+
+	traces, metrics := settings.TracesPathCfg, settings.MetricsPathCfg
+	if traces == "" {
+		traces = defaultTracesFilePath
+	}
+	if metrics == "" {
+		metrics = defaultMetricsFilePath
+	}
+	if filepath.Clean(traces) == filepath.Clean(metrics) {
+		return FileExporter{}, invalid("OTEL_EXPORTER_FILE_TRACES_PATH and OTEL_EXPORTER_FILE_METRICS_PATH must be different files")
+	}
+
+	exporter := FileExporter{Path: path}
+	if exporter.Path == "" {
+		exporter.Path = defaultPath
+	}
+
+	size, err := parseInt("OTEL_EXPORTER_FILE_MAX_SIZE", settings.MaxSizeCfg, defaultFileMaxSize, 1, ErrInvalidExporterConfig)
+	if err != nil {
+		return FileExporter{}, err
+	}
+	exporter.MaxSize = int64(size) << 20
+
+	if exporter.MaxBackups, err = parseInt("OTEL_EXPORTER_FILE_MAX_BACKUPS", settings.MaxBackupsCfg, defaultFileMaxBackups, 0, ErrInvalidExporterConfig); err != nil {
+		return FileExporter{}, err
+	}
+
+	return exporter, nil
+}
@@ -0,0 +1,95 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileExporter(t *testing.T) {
+	tests := []struct {
+		name            string
+		variables       map[string]string
+		expectedTraces  FileExporter
+		expectedMetrics FileExporter
+		expectedErr     error
+	}{
+		{
+			name:            "with default settings",
+			variables:       map[string]string{},
+			expectedTraces:  FileExporter{Path: "traces.jsonl", MaxSize: 100 << 20, MaxBackups: 5},
+			expectedMetrics: FileExporter{Path: "metrics.jsonl", MaxSize: 100 << 20, MaxBackups: 5},
+		},
+		{
+			name: "with custom settings",
+			variables: map[string]string{
+				"OTEL_EXPORTER_FILE_TRACES_PATH":  "/var/telemetry/traces.jsonl",
+				"OTEL_EXPORTER_FILE_METRICS_PATH": "/var/telemetry/metrics.jsonl",
+				"OTEL_EXPORTER_FILE_MAX_SIZE":     "10",
+				"OTEL_EXPORTER_FILE_MAX_BACKUPS":  "0",
+			},
+			expectedTraces:  FileExporter{Path: "/var/telemetry/traces.jsonl", MaxSize: 10 << 20, MaxBackups: 0},
+			expectedMetrics: FileExporter{Path: "/var/telemetry/metrics.jsonl", MaxSize: 10 << 20, MaxBackups: 0},
+		},
+		{
+			name: "with same path",
+			variables: map[string]string{
+				"OTEL_EXPORTER_FILE_TRACES_PATH":  "telemetry.jsonl",
+				"OTEL_EXPORTER_FILE_METRICS_PATH": "./telemetry.jsonl",
+			},
+			expectedErr: ErrInvalidExporterConfig,
+		},
+		{
+			name:        "with invalid max size",
+			variables:   map[string]string{"OTEL_EXPORTER_FILE_MAX_SIZE": "0"},
+			expectedErr: ErrInvalidExporterConfig,
+		},
+		{
+			name:        "with invalid max backups",
+			variables:   map[string]string{"OTEL_EXPORTER_FILE_MAX_BACKUPS": "-1"},
+			expectedErr: ErrInvalidExporterConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseMonitoringConfig(WithEnvironment(tt.variables))
+			require.NoError(t, err)
+
+			traces, err := cfg.TracesFileExporter()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTraces, traces)
+
+			metrics, err := cfg.MetricsFileExporter()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMetrics, metrics)
+		})
+	}
+}
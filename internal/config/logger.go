@@ -24,30 +24,135 @@ package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/confi
 
 type LoggerConfig interface {
 	LogLevel() string
+	PackageLevels() map[string]string
 	Service() string
+	// Size limits
+	MaxValueLength() int
+	MaxMetadataKeys() int
+	MaxDepth() int
+	MaxRecordSize() int
+	// Metadata layout
+	FlatMetadata() bool
+	MetadataCollisionPolicy() string
+	// Error reporting
+	ErrorReporting() bool
+	// Resource
+	ResourceAttributes() bool
 }
 type Logger struct {
-	LogLevelCfg string `env:"LOG_LEVEL" envDefault:"info"`
+	LogLevelCfg      string            `env:"LOG_LEVEL" envDefault:"info"`
+	PackageLevelsCfg map[string]string `env:"LOG_PACKAGE_LEVELS" envKeyValSeparator:"="` // Specifies the minimum log level of specific packages.
+	// Size limits configuration
+	MaxValueLengthCfg  int `env:"LOG_MAX_VALUE_LENGTH"`  // Specifies the maximum length of a single metadata value.
+	MaxMetadataKeysCfg int `env:"LOG_MAX_METADATA_KEYS"` // Specifies the maximum number of keys in a metadata group.
+	MaxDepthCfg        int `env:"LOG_MAX_DEPTH"`         // Specifies the maximum nesting depth of the metadata.
+	MaxRecordSizeCfg   int `env:"LOG_MAX_RECORD_SIZE"`   // Specifies the maximum size in bytes of a single log record.
+	// Metadata layout configuration
+	FlatMetadataCfg      bool   `env:"LOG_METADATA_FLAT"`                          // Specifies whether the metadata is emitted at the top level of the log record.
+	MetadataCollisionCfg string `env:"LOG_METADATA_COLLISION" envDefault:"prefix"` // Specifies how flat metadata keys that clash with reserved keys are handled.
+	// Error reporting configuration
+	ErrorReportingCfg bool `env:"LOG_ERROR_REPORTING"` // Specifies whether error logs are formatted for Google Cloud Error Reporting.
+	// Resource configuration
+	ResourceAttributesCfg bool `env:"LOG_RESOURCE_ATTRIBUTES"` // Specifies whether every resource attribute is added to the log records.
 
 	Monitoring
 }
 
 // LoggerConfig returns the Logger configuration from the environment.
 func NewLoggerConfig(opts ...Option) Logger {
-	cfg := Logger{}
-	if err := envParse(&cfg, opts...); err != nil {
+	cfg, err := ParseLoggerConfig(opts...)
+	if err != nil {
 		panic(err)
 	}
 	return cfg
 }
 
+// ParseLoggerConfig returns the Logger configuration from the environment.
+//
+// Unlike NewLoggerConfig, it returns the parsing errors instead of panicking.
+func ParseLoggerConfig(opts ...Option) (Logger, error) {
+	parseCfg := newParseConfig(opts)
+
+	cfg := Logger{}
+	if err := envParseWith(&cfg, parseCfg); err != nil {
+		return Logger{}, err
+	}
+	cfg.environment = parseCfg.environment
+
+	return cfg, nil
+}
+
+// Reparse parses the configuration again, with the environment it was parsed from,
+// so the changes of the environment and of the configuration file (see `FLYR_CONFIG_FILE`) are picked up.
+func (l Logger) Reparse() (Logger, error) {
+	return ParseLoggerConfig(WithEnvironment(l.environment))
+}
+
 // LogLevel returns the minimum log level for the logger.
 // Possible values could be error, warn, info, debug
 func (l Logger) LogLevel() string {
 	return l.LogLevelCfg
 }
 
+// PackageLevels returns the minimum log level of specific packages, keyed by the package path.
+//
+// The level of a package also applies to its sub-packages, unless they have their own level.
+func (l Logger) PackageLevels() map[string]string {
+	return l.PackageLevelsCfg
+}
+
 // Service returns the service name for application tagging.
 func (l Logger) Service() string {
 	return l.ServiceCfg
 }
+
+// MaxValueLength returns the maximum length of a single metadata value.
+//
+// A value less than or equal to zero disables the limit.
+func (l Logger) MaxValueLength() int {
+	return l.MaxValueLengthCfg
+}
+
+// MaxMetadataKeys returns the maximum number of keys in a metadata group.
+//
+// A value less than or equal to zero disables the limit.
+func (l Logger) MaxMetadataKeys() int {
+	return l.MaxMetadataKeysCfg
+}
+
+// MaxDepth returns the maximum nesting depth of the metadata.
+//
+// A value less than or equal to zero disables the limit.
+func (l Logger) MaxDepth() int {
+	return l.MaxDepthCfg
+}
+
+// MaxRecordSize returns the maximum size in bytes of a single log record.
+//
+// A value less than or equal to zero disables the limit.
+func (l Logger) MaxRecordSize() int {
+	return l.MaxRecordSizeCfg
+}
+
+// FlatMetadata returns whether the metadata is emitted at the top level of the log record,
+// instead of being nested under the "metadata" group.
+func (l Logger) FlatMetadata() bool {
+	return l.FlatMetadataCfg
+}
+
+// MetadataCollisionPolicy returns how flat metadata keys that clash with reserved keys are handled.
+// Possible values could be prefix, rename, drop
+func (l Logger) MetadataCollisionPolicy() string {
+	return l.MetadataCollisionCfg
+}
+
+// ErrorReporting returns whether error logs are formatted as Google Cloud Error Reporting events.
+func (l Logger) ErrorReporting() bool {
+	return l.ErrorReportingCfg
+}
+
+// ResourceAttributes returns whether every attribute of the resource shared with the traces and the metrics
+// (e.g. deployment.environment, k8s.*) is added to the log records, instead of only the service attributes.
+func (l Logger) ResourceAttributes() bool {
+	return l.ResourceAttributesCfg
+}
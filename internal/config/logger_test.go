@@ -33,16 +33,46 @@ func TestLoggerConfig(t *testing.T) {
 
 	assert.Equalf(t, "", cfg.Service(), "default Service() return value is not correct")
 	assert.Equalf(t, "info", cfg.LogLevel(), "default LogLevel() return value is not correct")
+	assert.Equalf(t, 0, cfg.MaxValueLength(), "default MaxValueLength() return value is not correct")
+	assert.Equalf(t, 0, cfg.MaxMetadataKeys(), "default MaxMetadataKeys() return value is not correct")
+	assert.Equalf(t, 0, cfg.MaxDepth(), "default MaxDepth() return value is not correct")
+	assert.Equalf(t, 0, cfg.MaxRecordSize(), "default MaxRecordSize() return value is not correct")
+	assert.Falsef(t, cfg.FlatMetadata(), "default FlatMetadata() return value is not correct")
+	assert.Equalf(t, "prefix", cfg.MetadataCollisionPolicy(), "default MetadataCollisionPolicy() return value is not correct")
+	assert.Falsef(t, cfg.ErrorReporting(), "default ErrorReporting() return value is not correct")
+	assert.Falsef(t, cfg.ResourceAttributes(), "default ResourceAttributes() return value is not correct")
+	assert.Emptyf(t, cfg.PackageLevels(), "default PackageLevels() return value is not correct")
 }
 
 func TestLoggerConfigWithEnvVars(t *testing.T) {
 	en := map[string]string{
-		"OTEL_SERVICE_NAME": "test-service",
-		"LOG_LEVEL":         "error",
+		"OTEL_SERVICE_NAME":       "test-service",
+		"LOG_LEVEL":               "error",
+		"LOG_MAX_VALUE_LENGTH":    "1024",
+		"LOG_MAX_METADATA_KEYS":   "50",
+		"LOG_MAX_DEPTH":           "5",
+		"LOG_MAX_RECORD_SIZE":     "262144",
+		"LOG_METADATA_FLAT":       "true",
+		"LOG_METADATA_COLLISION":  "drop",
+		"LOG_ERROR_REPORTING":     "true",
+		"LOG_RESOURCE_ATTRIBUTES": "true",
+		"LOG_PACKAGE_LEVELS":      "github.com/acme/db=debug,github.com/acme/http=warn",
 	}
 
-	cfg := NewLoggerConfig(withEnvironment(en))
+	cfg := NewLoggerConfig(WithEnvironment(en))
 
 	assert.Equalf(t, "test-service", cfg.Service(), "default Service() return value is not correct")
 	assert.Equalf(t, "error", cfg.LogLevel(), "default LogLevel() return value is not correct")
+	assert.Equalf(t, 1024, cfg.MaxValueLength(), "MaxValueLength() return value is not correct")
+	assert.Equalf(t, 50, cfg.MaxMetadataKeys(), "MaxMetadataKeys() return value is not correct")
+	assert.Equalf(t, 5, cfg.MaxDepth(), "MaxDepth() return value is not correct")
+	assert.Equalf(t, 262144, cfg.MaxRecordSize(), "MaxRecordSize() return value is not correct")
+	assert.Truef(t, cfg.FlatMetadata(), "FlatMetadata() return value is not correct")
+	assert.Equalf(t, "drop", cfg.MetadataCollisionPolicy(), "MetadataCollisionPolicy() return value is not correct")
+	assert.Truef(t, cfg.ErrorReporting(), "ErrorReporting() return value is not correct")
+	assert.Truef(t, cfg.ResourceAttributes(), "ResourceAttributes() return value is not correct")
+	assert.Equalf(t, map[string]string{
+		"github.com/acme/db":   "debug",
+		"github.com/acme/http": "warn",
+	}, cfg.PackageLevels(), "PackageLevels() return value is not correct")
 }
@@ -23,8 +23,13 @@
 package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
 )
 
 const (
@@ -32,6 +37,9 @@ const (
 	defaultMetricsInterval = float64(60.0)
 )
 
+// ErrInvalidSamplerConfig is returned when the sampler environment variables contain an invalid value.
+var ErrInvalidSamplerConfig = errors.New("invalid sampler configuration")
+
 var (
 	monitoringConfigInstance Monitoring
 	monitoringConfigOnce     sync.Once
@@ -48,26 +56,68 @@ type MonitoringConfig interface {
 	// Generic configuration
 	Service() string
 	IsTestExporter() bool
+	SDKDisabled() bool
 	// Traces configuration
 	ExporterTracesProtocol() string
+	TracesExporter() (OTLPExporter, error)
+	TracesExporterTypes() ([]string, error)
+	TracesFileExporter() (FileExporter, error)
+	TracesSampler() (string, error)
+	TracesSamplingRatio() (float64, error)
+	TracesSamplingRules() ([]SamplingRule, error)
+	TracesTailSampling() (TailSampling, error)
+	TracesScrubRules() ([]ScrubRule, error)
+	TracesViewerSpans() (int, error)
+	TracesBatchProcessor() (BatchProcessor, error)
+	TracesSpanLimits() (SpanLimits, error)
+	Propagators() ([]string, error)
 	EnableHttpClientTraces() bool
 	// Metrics configuration
 	ExporterMetricsProtocol() string
+	MetricsExporter() (OTLPExporter, error)
+	MetricsExporterTypes() ([]string, error)
+	MetricsFileExporter() (FileExporter, error)
 	MetricsInterval() time.Duration
+	// Middleware configuration
+	MiddlewareExcludedPaths() []string
 }
 
 type Monitoring struct {
 	// Generic configuration
 	ServiceCfg      string `env:"OTEL_SERVICE_NAME"`
 	TestExporterCfg bool   `env:"OTEL_EXPORTER_OTLP_TEST"` // Specifies whether the OTLP exporter should be used in test mode.
+	SDKDisabledCfg  bool   `env:"OTEL_SDK_DISABLED"`       // Disables the tracer and the meter.
 	// Exporter configuration
-	ExporterProtocolCfg string `env:"OTEL_EXPORTER_OTLP_PROTOCOL"` // Specifies the OTLP transport protocol to be used for all telemetry data.
+	ExporterProtocolCfg string               `env:"OTEL_EXPORTER_OTLP_PROTOCOL"` // Specifies the OTLP transport protocol to be used for all telemetry data.
+	ExporterCfg         ExporterSettings     `envPrefix:"OTEL_EXPORTER_OTLP_"`   // Specifies the OTLP exporter settings for all telemetry data.
+	FileExporterCfg     FileExporterSettings `envPrefix:"OTEL_EXPORTER_FILE_"`   // Specifies the settings of the file exporter.
 	// Traces configuration
-	ExporterTraceProtocolCfg  string `env:"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"` // Specifies the OTLP transport protocol to be used for trace data.
-	EnableHttpClientTracesCfg bool   `env:"OTEL_ENABLE_HTTP_CLIENT_TRACES"`     // Enables the DNS, connect, TLS, and get-connection traces in the "net/http.Client{}"
+	ExporterTraceProtocolCfg  string                 `env:"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"`    // Specifies the OTLP transport protocol to be used for trace data.
+	ExporterTracesCfg         ExporterSettings       `envPrefix:"OTEL_EXPORTER_OTLP_TRACES_"`      // Specifies the OTLP exporter settings for trace data.
+	TracesExporterTypesCfg    []string               `env:"OTEL_TRACES_EXPORTER" envSeparator:","` // Specifies the exporters used for trace data.
+	TracesSamplerCfg          string                 `env:"OTEL_TRACES_SAMPLER"`                   // Specifies the sampler of the traces.
+	TracesSamplerArgCfg       string                 `env:"OTEL_TRACES_SAMPLER_ARG"`               // Specifies the ratio of the traces which are sampled.
+	TracesSamplingRulesCfg    string                 `env:"OTEL_TRACES_SAMPLER_RULES"`             // Specifies the sampling rules by span name, kind or attribute.
+	TailSamplingCfg           TailSamplingSettings   `envPrefix:"OTEL_TRACES_TAIL_SAMPLING_"`      // Specifies the settings of the tail sampling.
+	TracesScrubRulesCfg       string                 `env:"OTEL_TRACES_SCRUB_RULES"`               // Specifies the rules which redact, hash or drop the span attributes.
+	TracesScrubHashKeyCfg     string                 `env:"OTEL_TRACES_SCRUB_HASH_KEY"`            // Specifies the key of the HMAC of the hashed span attributes.
+	TracesViewerSpansCfg      string                 `env:"OTEL_TRACES_VIEWER_SPANS"`              // Specifies the number of recent spans kept in memory for the trace viewer.
+	BatchProcessorCfg         BatchProcessorSettings `envPrefix:"OTEL_BSP_"`                       // Specifies the settings of the batch span processor.
+	SpanLimitsCfg             SpanLimitsSettings     `envPrefix:"OTEL_"`                           // Specifies the limits of the attributes, events and links of the spans.
+	PropagatorsCfg            []string               `env:"OTEL_PROPAGATORS" envSeparator:","`     // Specifies the propagators used to inject the trace context.
+	EnableHttpClientTracesCfg bool                   `env:"OTEL_ENABLE_HTTP_CLIENT_TRACES"`        // Enables the DNS, connect, TLS, and get-connection traces in the "net/http.Client{}"
 	// Metrics configuration
-	ExporterMetricsProtocolCfg string  `env:"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL"` // Specifies the OTLP transport protocol to be used for metric data.
-	MetricsIntervalCfg         float64 `env:"OTEL_METRICS_INTERVAL_SECONDS"`       // Specifies the interval at which metrics are exported.
+	ExporterMetricsProtocolCfg string           `env:"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL"`    // Specifies the OTLP transport protocol to be used for metric data.
+	ExporterMetricsCfg         ExporterSettings `envPrefix:"OTEL_EXPORTER_OTLP_METRICS_"`      // Specifies the OTLP exporter settings for metric data.
+	MetricsExporterTypesCfg    []string         `env:"OTEL_METRICS_EXPORTER" envSeparator:","` // Specifies the exporters used for metric data.
+	MetricsIntervalCfg         float64          `env:"OTEL_METRICS_INTERVAL_SECONDS"`          // Specifies the interval at which metrics are exported.
+	// Middleware configuration
+	MiddlewareExcludedPathsCfg []string `env:"OTEL_MIDDLEWARE_EXCLUDED_PATHS" envSeparator:","` // Specifies the request paths which are not traced by the middlewares.
+
+	// headerProvider is the provider of the exporter headers given with WithHeaderProvider.
+	headerProvider credentials.HeaderProvider
+	// environment is the environment given with WithEnvironment, or nil for the actual environment.
+	environment map[string]string
 }
 
 // NewMonitoringConfig returns a singleton instance of the Monitoring configuration.
@@ -78,8 +128,8 @@ type Monitoring struct {
 // Returns the singleton instance of the Monitoring configuration.
 func NewMonitoringConfig(opts ...Option) Monitoring {
 	monitoringConfigOnce.Do(func() {
-		cfg := Monitoring{}
-		if err := envParse(&cfg, opts...); err != nil {
+		cfg, err := ParseMonitoringConfig(opts...)
+		if err != nil {
 			panic(err)
 		}
 		monitoringConfigInstance = cfg
@@ -87,6 +137,29 @@ func NewMonitoringConfig(opts ...Option) Monitoring {
 	return monitoringConfigInstance
 }
 
+// ParseMonitoringConfig returns a new instance of the Monitoring configuration.
+//
+// Unlike NewMonitoringConfig, it reads the environment variables on every call and
+// returns the parsing errors instead of panicking.
+func ParseMonitoringConfig(opts ...Option) (Monitoring, error) {
+	parseCfg := newParseConfig(opts)
+
+	cfg := Monitoring{}
+	if err := envParseWith(&cfg, parseCfg); err != nil {
+		return Monitoring{}, err
+	}
+	cfg.headerProvider = parseCfg.headerProvider
+	cfg.environment = parseCfg.environment
+
+	return cfg, nil
+}
+
+// Reparse parses the configuration again, with the environment and the header provider it was parsed with,
+// so the changes of the environment and of the configuration file (see `FLYR_CONFIG_FILE`) are picked up.
+func (d Monitoring) Reparse() (Monitoring, error) {
+	return ParseMonitoringConfig(WithEnvironment(d.environment), WithHeaderProvider(d.headerProvider))
+}
+
 // Service returns the service name for application tagging.
 func (d Monitoring) Service() string {
 	return d.ServiceCfg
@@ -97,6 +170,11 @@ func (d Monitoring) IsTestExporter() bool {
 	return d.TestExporterCfg
 }
 
+// SDKDisabled returns whether the tracer and the meter are disabled.
+func (d Monitoring) SDKDisabled() bool {
+	return d.SDKDisabledCfg
+}
+
 // ExporterTracesProtocol returns the protocol used by the OTLP Trace exporter.
 //
 // If both `OTEL_EXPORTER_OTLP_PROTOCOL` and `OTEL_EXPORTER_OTLP_TRACES_PROTOCOL` are present,
@@ -110,6 +188,22 @@ func (d Monitoring) ExporterTracesProtocol() string {
 	return d.ExporterProtocolCfg
 }
 
+// TracesSamplingRatio returns the ratio of the traces which are sampled, read from `OTEL_TRACES_SAMPLER_ARG`.
+//
+// If the ratio is not set, all the traces are sampled. It returns an error if the ratio
+// is not a number between 0 and 1.
+func (d Monitoring) TracesSamplingRatio() (float64, error) {
+	if d.TracesSamplerArgCfg == "" {
+		return 1, nil
+	}
+
+	ratio, err := strconv.ParseFloat(d.TracesSamplerArgCfg, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return 0, fmt.Errorf("%w: OTEL_TRACES_SAMPLER_ARG must be a number between 0 and 1, got %q", ErrInvalidSamplerConfig, d.TracesSamplerArgCfg)
+	}
+	return ratio, nil
+}
+
 // ExporterMetricsProtocol returns the protocol used by the OTLP Metrics exporter.
 //
 // If both `OTEL_EXPORTER_OTLP_PROTOCOL` and `OTEL_EXPORTER_OTLP_METRICS_PROTOCOL` are present,
@@ -140,3 +234,8 @@ func (d Monitoring) MetricsInterval() time.Duration {
 func (d Monitoring) EnableHttpClientTraces() bool {
 	return d.EnableHttpClientTracesCfg
 }
+
+// MiddlewareExcludedPaths returns the request paths which are not traced by the middlewares.
+func (d Monitoring) MiddlewareExcludedPaths() []string {
+	return d.MiddlewareExcludedPathsCfg
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMonitoringConfig(t *testing.T) {
@@ -121,7 +122,7 @@ func TestMonitoringConfig(t *testing.T) {
 			// Reset the singleton state before each test
 			ResetMonitoringConfig()
 
-			cfg := NewMonitoringConfig(withEnvironment(tt.variables))
+			cfg := NewMonitoringConfig(WithEnvironment(tt.variables))
 
 			assert.Equalf(t, tt.expectedService, cfg.Service(), "Service() return value is not correct")
 			assert.Equalf(t, tt.expectedTraceExporter, cfg.ExporterTracesProtocol(), "ExporterTracesProtocol() return value is not correct")
@@ -180,8 +181,59 @@ func TestMetricsInterval(t *testing.T) {
 			// Reset the singleton state before each test
 			ResetMonitoringConfig()
 
-			cfg := NewMonitoringConfig(withEnvironment(tt.variables))
+			cfg := NewMonitoringConfig(WithEnvironment(tt.variables))
 			assert.Equalf(t, tt.expectedInterval, cfg.MetricsInterval(), "MetricsInterval() return value is not correct")
 		})
 	}
 }
+
+func TestTracesSamplingRatio(t *testing.T) {
+	tests := []struct {
+		name          string
+		variables     map[string]string
+		expectedRatio float64
+		expectedErr   error
+	}{
+		{
+			name:          "with default ratio",
+			variables:     map[string]string{},
+			expectedRatio: 1,
+		},
+		{
+			name: "with custom ratio",
+			variables: map[string]string{
+				"OTEL_TRACES_SAMPLER_ARG": "0.25",
+			},
+			expectedRatio: 0.25,
+		},
+		{
+			name: "with ratio out of range",
+			variables: map[string]string{
+				"OTEL_TRACES_SAMPLER_ARG": "1.5",
+			},
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+		{
+			name: "with invalid ratio",
+			variables: map[string]string{
+				"OTEL_TRACES_SAMPLER_ARG": "half",
+			},
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseMonitoringConfig(WithEnvironment(tt.variables))
+			require.NoError(t, err)
+
+			ratio, err := cfg.TracesSamplingRatio()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRatio, ratio)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// defaultMaxQueueSize is the default maximum number of spans waiting to be exported.
+	defaultMaxQueueSize = 2048
+	// defaultMaxExportBatchSize is the default maximum number of spans of an export.
+	defaultMaxExportBatchSize = 512
+	// defaultScheduleDelay is the default delay between two consecutive exports.
+	defaultScheduleDelay = 5 * time.Second
+	// defaultExportTimeout is the default maximum duration of an export.
+	defaultExportTimeout = 30 * time.Second
+	// defaultSpanLimit is the default maximum number of attributes, events and links of a span.
+	defaultSpanLimit = 128
+)
+
+// ErrInvalidSpanProcessorConfig is returned when the `OTEL_BSP_*` or `OTEL_*_LIMIT` environment variables contain an invalid value.
+var ErrInvalidSpanProcessorConfig = errors.New("invalid span processor configuration")
+
+// BatchProcessorSettings holds the raw settings of the batch span processor,
+// read from the `OTEL_BSP_*` environment variables.
+type BatchProcessorSettings struct {
+	MaxQueueSizeCfg       string `env:"MAX_QUEUE_SIZE"`        // The maximum number of spans waiting to be exported.
+	MaxExportBatchSizeCfg string `env:"MAX_EXPORT_BATCH_SIZE"` // The maximum number of spans of an export.
+	ScheduleDelayCfg      string `env:"SCHEDULE_DELAY"`        // The delay in milliseconds between two consecutive exports.
+	ExportTimeoutCfg      string `env:"EXPORT_TIMEOUT"`        // The maximum time in milliseconds of an export.
+}
+
+// BatchProcessor is the resolved configuration of the batch span processor.
+type BatchProcessor struct {
+	// MaxQueueSize is the maximum number of spans waiting to be exported; the spans above it are dropped.
+	MaxQueueSize int
+	// MaxExportBatchSize is the maximum number of spans of an export.
+	MaxExportBatchSize int
+	// ScheduleDelay is the delay between two consecutive exports.
+	ScheduleDelay time.Duration
+	// ExportTimeout is the maximum duration of an export.
+	ExportTimeout time.Duration
+}
+
+// SpanLimitsSettings holds the raw limits of the spans, read from the `OTEL_SPAN_*_LIMIT`,
+// `OTEL_EVENT_ATTRIBUTE_COUNT_LIMIT`, `OTEL_LINK_ATTRIBUTE_COUNT_LIMIT` and `OTEL_ATTRIBUTE_*_LIMIT` environment variables.
+type SpanLimitsSettings struct {
+	AttributeValueLengthCfg   string `env:"SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT"` // The maximum length of the string attribute values.
+	AttributeCountCfg         string `env:"SPAN_ATTRIBUTE_COUNT_LIMIT"`        // The maximum number of attributes of a span.
+	EventCountCfg             string `env:"SPAN_EVENT_COUNT_LIMIT"`            // The maximum number of events of a span.
+	LinkCountCfg              string `env:"SPAN_LINK_COUNT_LIMIT"`             // The maximum number of links of a span.
+	AttributePerEventCountCfg string `env:"EVENT_ATTRIBUTE_COUNT_LIMIT"`       // The maximum number of attributes of an event.
+	AttributePerLinkCountCfg  string `env:"LINK_ATTRIBUTE_COUNT_LIMIT"`        // The maximum number of attributes of a link.
+	// Generic limits, used if the span attribute limits are not set
+	GenericAttributeValueLengthCfg string `env:"ATTRIBUTE_VALUE_LENGTH_LIMIT"` // The maximum length of the string attribute values.
+	GenericAttributeCountCfg       string `env:"ATTRIBUTE_COUNT_LIMIT"`        // The maximum number of attributes.
+}
+
+// SpanLimits is the resolved configuration of the span limits.
+// A negative limit means that there is no limit.
+type SpanLimits struct {
+	// AttributeValueLength is the maximum length of the string attribute values; longer values are truncated.
+	AttributeValueLength int
+	// AttributeCount is the maximum number of attributes of a span.
+	AttributeCount int
+	// EventCount is the maximum number of events of a span.
+	EventCount int
+	// LinkCount is the maximum number of links of a span.
+	LinkCount int
+	// AttributePerEventCount is the maximum number of attributes of an event.
+	AttributePerEventCount int
+	// AttributePerLinkCount is the maximum number of attributes of a link.
+	AttributePerLinkCount int
+}
+
+// TracesBatchProcessor returns the configuration of the batch span processor, read from
+// `OTEL_BSP_MAX_QUEUE_SIZE`, `OTEL_BSP_MAX_EXPORT_BATCH_SIZE`, `OTEL_BSP_SCHEDULE_DELAY` and `OTEL_BSP_EXPORT_TIMEOUT`.
+//
+// The settings which are not set have the defaults of the OpenTelemetry specification: a queue of 2048 spans,
+// batches of 512 spans, a delay of 5 seconds and a timeout of 30 seconds.
+// It returns an ErrInvalidSpanProcessorConfig error if any of the settings is not a positive integer,
+// or if the batch size is larger than the queue size.
+func (d Monitoring) TracesBatchProcessor() (BatchProcessor, error) {
+	var err error
+	processor := BatchProcessor{}
+
+	if processor.MaxQueueSize, err = parseInt("OTEL_BSP_MAX_QUEUE_SIZE", d.BatchProcessorCfg.MaxQueueSizeCfg, defaultMaxQueueSize, 1, ErrInvalidSpanProcessorConfig); err != nil {
+		return BatchProcessor{}, err
+	}
+	if processor.MaxExportBatchSize, err = parseInt("OTEL_BSP_MAX_EXPORT_BATCH_SIZE", d.BatchProcessorCfg.MaxExportBatchSizeCfg, defaultMaxExportBatchSize, 1, ErrInvalidSpanProcessorConfig); err != nil {
+		return BatchProcessor{}, err
+	}
+	if processor.MaxExportBatchSize > processor.MaxQueueSize {
+		return BatchProcessor{}, fmt.Errorf("%w: OTEL_BSP_MAX_EXPORT_BATCH_SIZE (%d) must not be larger than OTEL_BSP_MAX_QUEUE_SIZE (%d)",
+			ErrInvalidSpanProcessorConfig, processor.MaxExportBatchSize, processor.MaxQueueSize)
+	}
+
+	delay, err := parseInt("OTEL_BSP_SCHEDULE_DELAY", d.BatchProcessorCfg.ScheduleDelayCfg, int(defaultScheduleDelay.Milliseconds()), 1, ErrInvalidSpanProcessorConfig)
+	if err != nil {
+		return BatchProcessor{}, err
+	}
+	processor.ScheduleDelay = time.Duration(delay) * time.Millisecond
+
+	timeout, err := parseInt("OTEL_BSP_EXPORT_TIMEOUT", d.BatchProcessorCfg.ExportTimeoutCfg, int(defaultExportTimeout.Milliseconds()), 1, ErrInvalidSpanProcessorConfig)
+	if err != nil {
+		return BatchProcessor{}, err
+	}
+	processor.ExportTimeout = time.Duration(timeout) * time.Millisecond
+
+	return processor, nil
+}
+
+// TracesSpanLimits returns the limits of the spans, read from the `OTEL_SPAN_*_LIMIT`,
+// `OTEL_EVENT_ATTRIBUTE_COUNT_LIMIT` and `OTEL_LINK_ATTRIBUTE_COUNT_LIMIT` environment variables.
+//
+// The limits which are not set have the defaults of the OpenTelemetry specification: no limit on the length
+// of the attribute values, and 128 attributes, events and links. The limits of the span attributes default to
+// the generic `OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT` and `OTEL_ATTRIBUTE_COUNT_LIMIT`, if set. A negative limit means that there is no limit.
+// It returns an ErrInvalidSpanProcessorConfig error if any of the limits is not an integer.
+func (d Monitoring) TracesSpanLimits() (SpanLimits, error) {
+	limits := SpanLimits{}
+
+	// the generic attribute limits are the defaults of the span attribute limits, as in the OpenTelemetry SDK
+	valueLength, err := parseInt("OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT", d.SpanLimitsCfg.GenericAttributeValueLengthCfg, -1, math.MinInt, ErrInvalidSpanProcessorConfig)
+	if err != nil {
+		return SpanLimits{}, err
+	}
+	count, err := parseInt("OTEL_ATTRIBUTE_COUNT_LIMIT", d.SpanLimitsCfg.GenericAttributeCountCfg, defaultSpanLimit, math.MinInt, ErrInvalidSpanProcessorConfig)
+	if err != nil {
+		return SpanLimits{}, err
+	}
+
+	if limits.AttributeValueLength, err = parseInt("OTEL_SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT", d.SpanLimitsCfg.AttributeValueLengthCfg, valueLength, math.MinInt, ErrInvalidSpanProcessorConfig); err != nil {
+		return SpanLimits{}, err
+	}
+	if limits.AttributeCount, err = parseInt("OTEL_SPAN_ATTRIBUTE_COUNT_LIMIT", d.SpanLimitsCfg.AttributeCountCfg, count, math.MinInt, ErrInvalidSpanProcessorConfig); err != nil {
+		return SpanLimits{}, err
+	}
+	if limits.EventCount, err = parseInt("OTEL_SPAN_EVENT_COUNT_LIMIT", d.SpanLimitsCfg.EventCountCfg, defaultSpanLimit, math.MinInt, ErrInvalidSpanProcessorConfig); err != nil {
+		return SpanLimits{}, err
+	}
+	if limits.LinkCount, err = parseInt("OTEL_SPAN_LINK_COUNT_LIMIT", d.SpanLimitsCfg.LinkCountCfg, defaultSpanLimit, math.MinInt, ErrInvalidSpanProcessorConfig); err != nil {
+		return SpanLimits{}, err
+	}
+	if limits.AttributePerEventCount, err = parseInt("OTEL_EVENT_ATTRIBUTE_COUNT_LIMIT", d.SpanLimitsCfg.AttributePerEventCountCfg, defaultSpanLimit, math.MinInt, ErrInvalidSpanProcessorConfig); err != nil {
+		return SpanLimits{}, err
+	}
+	if limits.AttributePerLinkCount, err = parseInt("OTEL_LINK_ATTRIBUTE_COUNT_LIMIT", d.SpanLimitsCfg.AttributePerLinkCountCfg, defaultSpanLimit, math.MinInt, ErrInvalidSpanProcessorConfig); err != nil {
+		return SpanLimits{}, err
+	}
+
+	return limits, nil
+}
+
+// TracesViewerSpans returns the number of recent spans kept in memory for the trace viewer, read from `OTEL_TRACES_VIEWER_SPANS`.
+//
+// The viewer is disabled if the value is not set, is zero, or if `OTEL_SDK_DISABLED` is true.
+//
+// It returns an ErrInvalidSpanProcessorConfig error if the value is not a positive integer or zero.
+func (d Monitoring) TracesViewerSpans() (int, error) {
+	if d.SDKDisabled() || d.TracesViewerSpansCfg == "" || d.TracesViewerSpansCfg == "0" {
+		return 0, nil
+	}
+
+	return parseInt("OTEL_TRACES_VIEWER_SPANS", d.TracesViewerSpansCfg, 0, 1, ErrInvalidSpanProcessorConfig)
+}
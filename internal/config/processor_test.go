@@ -0,0 +1,258 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracesBatchProcessor(t *testing.T) {
+	tests := []struct {
+		name              string
+		variables         map[string]string
+		expectedProcessor BatchProcessor
+		expectedErr       error
+	}{
+		{
+			name:      "with default settings",
+			variables: map[string]string{},
+			expectedProcessor: BatchProcessor{
+				MaxQueueSize:       2048,
+				MaxExportBatchSize: 512,
+				ScheduleDelay:      5 * time.Second,
+				ExportTimeout:      30 * time.Second,
+			},
+		},
+		{
+			name: "with custom settings",
+			variables: map[string]string{
+				"OTEL_BSP_MAX_QUEUE_SIZE":        "8192",
+				"OTEL_BSP_MAX_EXPORT_BATCH_SIZE": "1024",
+				"OTEL_BSP_SCHEDULE_DELAY":        "1000",
+				"OTEL_BSP_EXPORT_TIMEOUT":        "5000",
+			},
+			expectedProcessor: BatchProcessor{
+				MaxQueueSize:       8192,
+				MaxExportBatchSize: 1024,
+				ScheduleDelay:      time.Second,
+				ExportTimeout:      5 * time.Second,
+			},
+		},
+		{
+			name: "with invalid queue size",
+			variables: map[string]string{
+				"OTEL_BSP_MAX_QUEUE_SIZE": "large",
+			},
+			expectedErr: ErrInvalidSpanProcessorConfig,
+		},
+		{
+			name: "with zero schedule delay",
+			variables: map[string]string{
+				"OTEL_BSP_SCHEDULE_DELAY": "0",
+			},
+			expectedErr: ErrInvalidSpanProcessorConfig,
+		},
+		{
+			name: "with batch size larger than queue size",
+			variables: map[string]string{
+				"OTEL_BSP_MAX_QUEUE_SIZE":        "100",
+				"OTEL_BSP_MAX_EXPORT_BATCH_SIZE": "200",
+			},
+			expectedErr: ErrInvalidSpanProcessorConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseMonitoringConfig(WithEnvironment(tt.variables))
+			require.NoError(t, err)
+
+			processor, err := cfg.TracesBatchProcessor()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedProcessor, processor)
+		})
+	}
+}
+
+func TestTracesSpanLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		variables      map[string]string
+		expectedLimits SpanLimits
+		expectedErr    error
+	}{
+		{
+			name:      "with default limits",
+			variables: map[string]string{},
+			expectedLimits: SpanLimits{
+				AttributeValueLength:   -1,
+				AttributeCount:         128,
+				EventCount:             128,
+				LinkCount:              128,
+				AttributePerEventCount: 128,
+				AttributePerLinkCount:  128,
+			},
+		},
+		{
+			name: "with custom limits",
+			variables: map[string]string{
+				"OTEL_SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT": "256",
+				"OTEL_SPAN_ATTRIBUTE_COUNT_LIMIT":        "64",
+				"OTEL_SPAN_EVENT_COUNT_LIMIT":            "-1",
+				"OTEL_SPAN_LINK_COUNT_LIMIT":             "0",
+				"OTEL_EVENT_ATTRIBUTE_COUNT_LIMIT":       "16",
+				"OTEL_LINK_ATTRIBUTE_COUNT_LIMIT":        "8",
+			},
+			expectedLimits: SpanLimits{
+				AttributeValueLength:   256,
+				AttributeCount:         64,
+				EventCount:             -1,
+				LinkCount:              0,
+				AttributePerEventCount: 16,
+				AttributePerLinkCount:  8,
+			},
+		},
+		{
+			name: "with generic attribute limits",
+			variables: map[string]string{
+				"OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT": "512",
+				"OTEL_ATTRIBUTE_COUNT_LIMIT":        "32",
+			},
+			expectedLimits: SpanLimits{
+				AttributeValueLength:   512,
+				AttributeCount:         32,
+				EventCount:             128,
+				LinkCount:              128,
+				AttributePerEventCount: 128,
+				AttributePerLinkCount:  128,
+			},
+		},
+		{
+			name: "with span attribute limits overriding the generic ones",
+			variables: map[string]string{
+				"OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT":      "512",
+				"OTEL_ATTRIBUTE_COUNT_LIMIT":             "32",
+				"OTEL_SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT": "256",
+				"OTEL_SPAN_ATTRIBUTE_COUNT_LIMIT":        "64",
+			},
+			expectedLimits: SpanLimits{
+				AttributeValueLength:   256,
+				AttributeCount:         64,
+				EventCount:             128,
+				LinkCount:              128,
+				AttributePerEventCount: 128,
+				AttributePerLinkCount:  128,
+			},
+		},
+		{
+			name: "with invalid limit",
+			variables: map[string]string{
+				"OTEL_SPAN_EVENT_COUNT_LIMIT": "many",
+			},
+			expectedErr: ErrInvalidSpanProcessorConfig,
+		},
+		{
+			name: "with invalid generic limit",
+			variables: map[string]string{
+				"OTEL_ATTRIBUTE_COUNT_LIMIT": "many",
+			},
+			expectedErr: ErrInvalidSpanProcessorConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseMonitoringConfig(WithEnvironment(tt.variables))
+			require.NoError(t, err)
+
+			limits, err := cfg.TracesSpanLimits()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLimits, limits)
+		})
+	}
+}
+
+func TestTracesViewerSpans(t *testing.T) {
+	tests := []struct {
+		name          string
+		variables     map[string]string
+		expectedSpans int
+		expectedErr   error
+	}{
+		{
+			name:          "without viewer",
+			variables:     map[string]string{},
+			expectedSpans: 0,
+		},
+		{
+			name:          "with viewer",
+			variables:     map[string]string{"OTEL_TRACES_VIEWER_SPANS": "1000"},
+			expectedSpans: 1000,
+		},
+		{
+			name:          "with disabled viewer",
+			variables:     map[string]string{"OTEL_TRACES_VIEWER_SPANS": "0"},
+			expectedSpans: 0,
+		},
+		{
+			name: "with disabled sdk",
+			variables: map[string]string{
+				"OTEL_TRACES_VIEWER_SPANS": "1000",
+				"OTEL_SDK_DISABLED":        "true",
+			},
+			expectedSpans: 0,
+		},
+		{
+			name:        "with invalid number of spans",
+			variables:   map[string]string{"OTEL_TRACES_VIEWER_SPANS": "-1"},
+			expectedErr: ErrInvalidSpanProcessorConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseMonitoringConfig(WithEnvironment(tt.variables))
+			require.NoError(t, err)
+
+			spans, err := cfg.TracesViewerSpans()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSpans, spans)
+		})
+	}
+}
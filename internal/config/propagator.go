@@ -0,0 +1,98 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// PropagatorTraceContext is the W3C Trace Context propagator (the `traceparent` and `tracestate` headers).
+	PropagatorTraceContext = "tracecontext"
+	// PropagatorBaggage is the W3C Baggage propagator (the `baggage` header).
+	PropagatorBaggage = "baggage"
+	// PropagatorB3 is the B3 propagator with a single header (the `b3` header).
+	PropagatorB3 = "b3"
+	// PropagatorB3Multi is the B3 propagator with multiple headers (the `x-b3-*` headers).
+	PropagatorB3Multi = "b3multi"
+	// PropagatorJaeger is the Jaeger propagator (the `uber-trace-id` header).
+	PropagatorJaeger = "jaeger"
+	// PropagatorCloudTrace is the Google Cloud Trace propagator (the `x-cloud-trace-context` header).
+	PropagatorCloudTrace = "cloudtrace"
+	// PropagatorNone disables the propagation.
+	PropagatorNone = "none"
+)
+
+// ErrInvalidPropagatorConfig is returned when `OTEL_PROPAGATORS` contains an invalid value.
+var ErrInvalidPropagatorConfig = errors.New("invalid propagator configuration")
+
+// SupportedPropagators are the supported propagators, in the default order of precedence.
+var SupportedPropagators = []string{
+	PropagatorTraceContext,
+	PropagatorBaggage,
+	PropagatorB3,
+	PropagatorB3Multi,
+	PropagatorJaeger,
+	PropagatorCloudTrace,
+}
+
+// Propagators returns the propagators used to inject the context in the outgoing requests and messages,
+// read from `OTEL_PROPAGATORS`.
+//
+// The accepted values are tracecontext, baggage, b3, b3multi, jaeger, cloudtrace and none, and more
+// than one propagator can be given (except for none). If no value is given, tracecontext and baggage are used.
+// If none is given, it returns no propagators.
+func (d Monitoring) Propagators() ([]string, error) {
+	propagators := make([]string, 0, len(d.PropagatorsCfg))
+	seen := map[string]bool{}
+	for _, value := range d.PropagatorsCfg {
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch value {
+		case "":
+			continue
+		case PropagatorTraceContext, PropagatorBaggage, PropagatorB3, PropagatorB3Multi,
+			PropagatorJaeger, PropagatorCloudTrace, PropagatorNone:
+		default:
+			return nil, fmt.Errorf("%w: OTEL_PROPAGATORS contains an unsupported propagator %q", ErrInvalidPropagatorConfig, value)
+		}
+
+		if !seen[value] {
+			seen[value] = true
+			propagators = append(propagators, value)
+		}
+	}
+
+	if len(propagators) == 0 {
+		return []string{PropagatorTraceContext, PropagatorBaggage}, nil
+	}
+	if seen[PropagatorNone] {
+		if len(propagators) > 1 {
+			return nil, fmt.Errorf("%w: OTEL_PROPAGATORS cannot combine none with other propagators", ErrInvalidPropagatorConfig)
+		}
+		return []string{}, nil
+	}
+
+	return propagators, nil
+}
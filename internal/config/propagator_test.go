@@ -0,0 +1,88 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropagators(t *testing.T) {
+	tests := []struct {
+		name                string
+		variables           map[string]string
+		expectedPropagators []string
+		expectedErr         error
+	}{
+		{
+			name:                "with default propagators",
+			variables:           map[string]string{},
+			expectedPropagators: []string{PropagatorTraceContext, PropagatorBaggage},
+		},
+		{
+			name: "with custom propagators",
+			variables: map[string]string{
+				"OTEL_PROPAGATORS": "B3Multi, cloudtrace,b3multi",
+			},
+			expectedPropagators: []string{PropagatorB3Multi, PropagatorCloudTrace},
+		},
+		{
+			name: "with none",
+			variables: map[string]string{
+				"OTEL_PROPAGATORS": "none",
+			},
+			expectedPropagators: []string{},
+		},
+		{
+			name: "with none and other propagators",
+			variables: map[string]string{
+				"OTEL_PROPAGATORS": "none,b3",
+			},
+			expectedErr: ErrInvalidPropagatorConfig,
+		},
+		{
+			name: "with unsupported propagator",
+			variables: map[string]string{
+				"OTEL_PROPAGATORS": "xray",
+			},
+			expectedErr: ErrInvalidPropagatorConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseMonitoringConfig(WithEnvironment(tt.variables))
+			require.NoError(t, err)
+
+			propagators, err := cfg.Propagators()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPropagators, propagators)
+		})
+	}
+}
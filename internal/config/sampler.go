@@ -0,0 +1,155 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SamplerAlwaysOn samples every span.
+	SamplerAlwaysOn = "always_on"
+	// SamplerAlwaysOff samples no span.
+	SamplerAlwaysOff = "always_off"
+	// SamplerTraceIDRatio samples a ratio of the traces, given in `OTEL_TRACES_SAMPLER_ARG`.
+	SamplerTraceIDRatio = "traceidratio"
+	// SamplerParentBasedAlwaysOn follows the decision of the parent, and samples every root span.
+	SamplerParentBasedAlwaysOn = "parentbased_always_on"
+	// SamplerParentBasedAlwaysOff follows the decision of the parent, and samples no root span.
+	SamplerParentBasedAlwaysOff = "parentbased_always_off"
+	// SamplerParentBasedTraceIDRatio follows the decision of the parent, and samples a ratio of the root spans.
+	SamplerParentBasedTraceIDRatio = "parentbased_traceidratio"
+)
+
+// TracesSampler returns the sampler of the traces, read from `OTEL_TRACES_SAMPLER`.
+//
+// The accepted values are always_on, always_off, traceidratio, parentbased_always_on,
+// parentbased_always_off and parentbased_traceidratio. If the sampler is not set,
+// parentbased_traceidratio is used, which samples all the traces unless `OTEL_TRACES_SAMPLER_ARG` is set.
+func (d Monitoring) TracesSampler() (string, error) {
+	sampler := strings.ToLower(strings.TrimSpace(d.TracesSamplerCfg))
+
+	switch sampler {
+	case "":
+		return SamplerParentBasedTraceIDRatio, nil
+	case SamplerAlwaysOn, SamplerAlwaysOff, SamplerTraceIDRatio,
+		SamplerParentBasedAlwaysOn, SamplerParentBasedAlwaysOff, SamplerParentBasedTraceIDRatio:
+		return sampler, nil
+	default:
+		return "", fmt.Errorf("%w: OTEL_TRACES_SAMPLER contains an unsupported sampler %q", ErrInvalidSamplerConfig, d.TracesSamplerCfg)
+	}
+}
+
+// SamplingRule is a rule that decides the sampling of the spans which match all of its conditions.
+// A condition which is not set matches any span.
+type SamplingRule struct {
+	// Name matches the span name. A trailing "*" matches any span name with the given prefix.
+	Name string
+	// Kind matches the span kind (internal, server, client, producer or consumer).
+	Kind string
+	// Attributes match the attributes given when the span is started.
+	Attributes map[string]string
+	// Ratio is the ratio of the matching traces which are sampled; 1 for always_on and 0 for always_off.
+	Ratio float64
+}
+
+// TracesSamplingRules returns the sampling rules, read from `OTEL_TRACES_SAMPLER_RULES`.
+//
+// The rules are separated by semicolons, and each rule has the form `<decision>:<conditions>`,
+// where the decision is always_on, always_off or a ratio between 0 and 1, and the conditions
+// are comma separated `name=<span name>`, `kind=<span kind>` or `attr.<key>=<value>`. For example:
+//
+//	always_on:name=POST /checkout;always_off:attr.http.route=/healthz;0.1:kind=client
+//
+// It returns an ErrInvalidSamplerConfig error if any of the rules is invalid.
+func (d Monitoring) TracesSamplingRules() ([]SamplingRule, error) {
+	var rules []SamplingRule
+
+	for _, value := range strings.Split(d.TracesSamplingRulesCfg, ";") {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		rule, err := parseSamplingRule(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: OTEL_TRACES_SAMPLER_RULES contains an invalid rule %q: %s", ErrInvalidSamplerConfig, value, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// parseSamplingRule parses a single rule of `OTEL_TRACES_SAMPLER_RULES`.
+func parseSamplingRule(value string) (SamplingRule, error) {
+	decision, conditions, ok := strings.Cut(value, ":")
+	if !ok {
+		return SamplingRule{}, fmt.Errorf("the decision and the conditions must be separated by a colon")
+	}
+
+	rule := SamplingRule{}
+	switch decision = strings.TrimSpace(decision); decision {
+	case SamplerAlwaysOn:
+		rule.Ratio = 1
+	case SamplerAlwaysOff:
+		rule.Ratio = 0
+	default:
+		ratio, err := strconv.ParseFloat(decision, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return SamplingRule{}, fmt.Errorf("the decision must be always_on, always_off or a ratio between 0 and 1")
+		}
+		rule.Ratio = ratio
+	}
+
+	for _, condition := range strings.Split(conditions, ",") {
+		key, v, ok := strings.Cut(condition, "=")
+		key = strings.TrimSpace(key)
+		v = strings.TrimSpace(v)
+		if !ok || v == "" {
+			return SamplingRule{}, fmt.Errorf("the condition %q must have the form key=value", condition)
+		}
+
+		switch {
+		case key == "name":
+			rule.Name = v
+		case key == "kind":
+			switch v = strings.ToLower(v); v {
+			case "internal", "server", "client", "producer", "consumer":
+				rule.Kind = v
+			default:
+				return SamplingRule{}, fmt.Errorf("the span kind %q is not supported", v)
+			}
+		case strings.HasPrefix(key, "attr.") && len(key) > len("attr."):
+			if rule.Attributes == nil {
+				rule.Attributes = map[string]string{}
+			}
+			rule.Attributes[strings.TrimPrefix(key, "attr.")] = v
+		default:
+			return SamplingRule{}, fmt.Errorf("the condition %q is not supported", key)
+		}
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,146 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracesSampler(t *testing.T) {
+	tests := []struct {
+		name            string
+		variables       map[string]string
+		expectedSampler string
+		expectedErr     error
+	}{
+		{
+			name:            "with default sampler",
+			variables:       map[string]string{},
+			expectedSampler: SamplerParentBasedTraceIDRatio,
+		},
+		{
+			name: "with always on sampler",
+			variables: map[string]string{
+				"OTEL_TRACES_SAMPLER": "always_on",
+			},
+			expectedSampler: SamplerAlwaysOn,
+		},
+		{
+			name: "with upper case sampler",
+			variables: map[string]string{
+				"OTEL_TRACES_SAMPLER": "ParentBased_Always_Off",
+			},
+			expectedSampler: SamplerParentBasedAlwaysOff,
+		},
+		{
+			name: "with unsupported sampler",
+			variables: map[string]string{
+				"OTEL_TRACES_SAMPLER": "jaeger_remote",
+			},
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseMonitoringConfig(WithEnvironment(tt.variables))
+			require.NoError(t, err)
+
+			sampler, err := cfg.TracesSampler()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSampler, sampler)
+		})
+	}
+}
+
+func TestTracesSamplingRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         string
+		expectedRules []SamplingRule
+		expectedErr   error
+	}{
+		{
+			name:  "without rules",
+			rules: "",
+		},
+		{
+			name:  "with rules",
+			rules: "always_on:name=POST /checkout; always_off:attr.http.route=/healthz;0.1:kind=Client,name=db.*",
+			expectedRules: []SamplingRule{
+				{Name: "POST /checkout", Ratio: 1},
+				{Attributes: map[string]string{"http.route": "/healthz"}, Ratio: 0},
+				{Name: "db.*", Kind: "client", Ratio: 0.1},
+			},
+		},
+		{
+			name:        "without decision",
+			rules:       "name=POST /checkout",
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+		{
+			name:        "with ratio out of range",
+			rules:       "2:name=POST /checkout",
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+		{
+			name:        "without conditions",
+			rules:       "always_on:",
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+		{
+			name:        "with unsupported span kind",
+			rules:       "always_on:kind=remote",
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+		{
+			name:        "with unsupported condition",
+			rules:       "always_on:status=error",
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseMonitoringConfig(WithEnvironment(map[string]string{
+				"OTEL_TRACES_SAMPLER_RULES": tt.rules,
+			}))
+			require.NoError(t, err)
+
+			rules, err := cfg.TracesSamplingRules()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRules, rules)
+		})
+	}
+}
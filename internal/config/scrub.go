@@ -0,0 +1,134 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ScrubRedact replaces the value of the attribute with a placeholder.
+	ScrubRedact = "redact"
+	// ScrubHash replaces the value of the attribute with its HMAC-SHA256, keyed with `OTEL_TRACES_SCRUB_HASH_KEY`.
+	ScrubHash = "hash"
+	// ScrubDrop removes the attribute.
+	ScrubDrop = "drop"
+)
+
+// ErrInvalidScrubConfig is returned when the scrubbing rules contain an invalid value.
+var ErrInvalidScrubConfig = errors.New("invalid scrubbing configuration")
+
+// ScrubRule is a rule that redacts, hashes or drops the span and event attributes which match all of its conditions.
+// A condition which is not set matches any attribute, but a rule has at least one condition.
+type ScrubRule struct {
+	// Action is ScrubRedact, ScrubHash or ScrubDrop.
+	Action string
+	// Key matches the attribute key. A "*" matches any sequence of characters, e.g. "*.email".
+	Key string
+	// Value matches the attribute value, as a string.
+	Value *regexp.Regexp
+	// HashKey is the key of the HMAC which replaces the value, if Action is ScrubHash.
+	HashKey []byte
+}
+
+// TracesScrubRules returns the scrubbing rules of the span attributes, read from `OTEL_TRACES_SCRUB_RULES`.
+//
+// The rules are separated by semicolons, and each rule has the form `<action>:<conditions>`,
+// where the action is redact, hash or drop, and the conditions are `key=<pattern>` and/or `value=<regex>`,
+// separated by a comma. The value condition comes last, so that the regular expression can contain commas. For example:
+//
+//	redact:key=*.email;hash:key=user.id;redact:value=[^@\s]+@[^@\s]+
+//
+// The hash action replaces the value with its HMAC-SHA256, keyed with `OTEL_TRACES_SCRUB_HASH_KEY`, so that
+// the hashed values can't be recovered by hashing the likely values without the key.
+//
+// It returns an ErrInvalidScrubConfig error if any of the rules is invalid, or if a rule hashes the values
+// and `OTEL_TRACES_SCRUB_HASH_KEY` is not set.
+func (d Monitoring) TracesScrubRules() ([]ScrubRule, error) {
+	var rules []ScrubRule
+
+	for _, value := range strings.Split(d.TracesScrubRulesCfg, ";") {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		rule, err := parseScrubRule(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: OTEL_TRACES_SCRUB_RULES contains an invalid rule %q: %s", ErrInvalidScrubConfig, value, err)
+		}
+		if rule.Action == ScrubHash {
+			if d.TracesScrubHashKeyCfg == "" {
+				return nil, fmt.Errorf("%w: OTEL_TRACES_SCRUB_HASH_KEY must be set to hash the values of rule %q", ErrInvalidScrubConfig, value)
+			}
+			rule.HashKey = []byte(d.TracesScrubHashKeyCfg)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// parseScrubRule parses a single rule of `OTEL_TRACES_SCRUB_RULES`.
+func parseScrubRule(value string) (ScrubRule, error) {
+	action, conditions, ok := strings.Cut(value, ":")
+	if !ok {
+		return ScrubRule{}, fmt.Errorf("the action and the conditions must be separated by a colon")
+	}
+
+	rule := ScrubRule{}
+	switch action = strings.ToLower(strings.TrimSpace(action)); action {
+	case ScrubRedact, ScrubHash, ScrubDrop:
+		rule.Action = action
+	default:
+		return ScrubRule{}, fmt.Errorf("the action must be redact, hash or drop")
+	}
+
+	conditions = strings.TrimSpace(conditions)
+	if key, ok := strings.CutPrefix(conditions, "key="); ok {
+		key, conditions, _ = strings.Cut(key, ",")
+		if rule.Key = strings.TrimSpace(key); rule.Key == "" {
+			return ScrubRule{}, fmt.Errorf("the key pattern must not be empty")
+		}
+		conditions = strings.TrimSpace(conditions)
+	}
+	if pattern, ok := strings.CutPrefix(conditions, "value="); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil || pattern == "" {
+			return ScrubRule{}, fmt.Errorf("the value must be a valid regular expression")
+		}
+		rule.Value = re
+		conditions = ""
+	}
+
+	if conditions != "" {
+		return ScrubRule{}, fmt.Errorf("the conditions must be key=<pattern> and/or value=<regex>")
+	}
+	if rule.Key == "" && rule.Value == nil {
+		return ScrubRule{}, fmt.Errorf("the rule must have a condition")
+	}
+
+	return rule, nil
+}
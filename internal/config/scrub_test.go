@@ -0,0 +1,122 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracesScrubRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         string
+		expectedRules []ScrubRule
+		expectedValue []string
+		withoutKey    bool
+		expectedErr   error
+	}{
+		{
+			name:  "without rules",
+			rules: "",
+		},
+		{
+			name:  "with key and value rules",
+			rules: `redact:key=*.email; HASH:key=user.id ;drop:key=passenger.*,value=^[A-Z]{2,3}$;redact:value=[^@\s]+@[^@\s]+`,
+			expectedRules: []ScrubRule{
+				{Action: ScrubRedact, Key: "*.email"},
+				{Action: ScrubHash, Key: "user.id"},
+				{Action: ScrubDrop, Key: "passenger.*"},
+				{Action: ScrubRedact},
+			},
+			expectedValue: []string{"", "", "^[A-Z]{2,3}$", `[^@\s]+@[^@\s]+`},
+		},
+		{
+			name:        "with hash rule without key",
+			rules:       "hash:key=user.id",
+			withoutKey:  true,
+			expectedErr: ErrInvalidScrubConfig,
+		},
+		{
+			name:        "without colon",
+			rules:       "redact",
+			expectedErr: ErrInvalidScrubConfig,
+		},
+		{
+			name:        "with unsupported action",
+			rules:       "mask:key=user.email",
+			expectedErr: ErrInvalidScrubConfig,
+		},
+		{
+			name:        "without condition",
+			rules:       "redact:",
+			expectedErr: ErrInvalidScrubConfig,
+		},
+		{
+			name:        "with unsupported condition",
+			rules:       "redact:name=checkout",
+			expectedErr: ErrInvalidScrubConfig,
+		},
+		{
+			name:        "with invalid regular expression",
+			rules:       "redact:value=[a-",
+			expectedErr: ErrInvalidScrubConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			environment := map[string]string{"OTEL_TRACES_SCRUB_RULES": tt.rules}
+			if !tt.withoutKey {
+				environment["OTEL_TRACES_SCRUB_HASH_KEY"] = "secret"
+			}
+			cfg, err := ParseMonitoringConfig(WithEnvironment(environment))
+			require.NoError(t, err)
+
+			rules, err := cfg.TracesScrubRules()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, rules, len(tt.expectedRules))
+
+			for i, rule := range rules {
+				assert.Equal(t, tt.expectedRules[i].Action, rule.Action)
+				assert.Equal(t, tt.expectedRules[i].Key, rule.Key)
+				if rule.Action == ScrubHash {
+					assert.Equal(t, []byte("secret"), rule.HashKey)
+				} else {
+					assert.Nil(t, rule.HashKey)
+				}
+				if tt.expectedValue[i] == "" {
+					assert.Nil(t, rule.Value)
+				} else {
+					assert.Equal(t, tt.expectedValue[i], rule.Value.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultDecisionWait is the default maximum time the spans of a trace are buffered before the decision.
+	defaultDecisionWait = 10 * time.Second
+	// defaultTailSamplingRatio is the default ratio of the traces which are sampled without matching any policy.
+	defaultTailSamplingRatio = 0.1
+	// defaultMaxBufferedTraces is the default maximum number of traces buffered at the same time.
+	defaultMaxBufferedTraces = 10000
+	// defaultMaxBufferedSpans is the default maximum number of spans buffered at the same time.
+	defaultMaxBufferedSpans = 100000
+)
+
+// TailSamplingSettings holds the raw settings of the tail sampling, read from the `OTEL_TRACES_TAIL_SAMPLING_*` environment variables.
+type TailSamplingSettings struct {
+	EnabledCfg          bool   `env:"ENABLED"`           // Whether the spans are buffered per trace and sampled once the trace is complete.
+	DecisionWaitCfg     string `env:"DECISION_WAIT"`     // The maximum time in milliseconds the spans of a trace are buffered before the decision.
+	LatencyThresholdCfg string `env:"LATENCY_THRESHOLD"` // The duration in milliseconds above which a trace is always sampled.
+	AttributesCfg       string `env:"ATTRIBUTES"`        // Comma separated key=value attributes of the traces which are always sampled.
+	RatioCfg            string `env:"RATIO"`             // The ratio of the other traces which are sampled.
+	MaxTracesCfg        string `env:"MAX_TRACES"`        // The maximum number of traces buffered at the same time.
+	MaxSpansCfg         string `env:"MAX_SPANS"`         // The maximum number of spans buffered at the same time.
+}
+
+// TailSampling is the resolved configuration of the tail sampling.
+type TailSampling struct {
+	// Enabled is whether the spans are buffered per trace and sampled once the trace is complete.
+	Enabled bool
+	// DecisionWait is the maximum time the spans of a trace are buffered before the decision,
+	// if the trace is not complete before.
+	DecisionWait time.Duration
+	// LatencyThreshold is the duration of a span above which its trace is always sampled. Zero disables the policy.
+	LatencyThreshold time.Duration
+	// Attributes are the attributes of a span which make its trace always sampled.
+	Attributes map[string]string
+	// Ratio is the ratio of the traces which are sampled without matching any policy.
+	Ratio float64
+	// MaxTraces is the maximum number of traces buffered at the same time.
+	MaxTraces int
+	// MaxSpans is the maximum number of spans buffered at the same time.
+	MaxSpans int
+}
+
+// TracesTailSampling returns the configuration of the tail sampling, read from the `OTEL_TRACES_TAIL_SAMPLING_*` environment variables.
+//
+// The traces with an error status, a span longer than the latency threshold or a span with any of the attributes are
+// always sampled, and a ratio (10% by default) of the other traces. The spans of a trace are buffered until the trace
+// is complete, or for 10 seconds by default, with at most 10000 traces and 100000 spans buffered at the same time.
+//
+// It returns an ErrInvalidSamplerConfig error if any of the settings is invalid.
+func (d Monitoring) TracesTailSampling() (TailSampling, error) {
+	settings := d.TailSamplingCfg
+	tail := TailSampling{
+		Enabled: settings.EnabledCfg,
+		Ratio:   defaultTailSamplingRatio,
+	}
+
+	wait, err := parseInt("OTEL_TRACES_TAIL_SAMPLING_DECISION_WAIT", settings.DecisionWaitCfg, int(defaultDecisionWait.Milliseconds()), 1, ErrInvalidSamplerConfig)
+	if err != nil {
+		return TailSampling{}, err
+	}
+	tail.DecisionWait = time.Duration(wait) * time.Millisecond
+
+	threshold, err := parseInt("OTEL_TRACES_TAIL_SAMPLING_LATENCY_THRESHOLD", settings.LatencyThresholdCfg, 0, 0, ErrInvalidSamplerConfig)
+	if err != nil {
+		return TailSampling{}, err
+	}
+	tail.LatencyThreshold = time.Duration(threshold) * time.Millisecond
+
+	if tail.MaxTraces, err = parseInt("OTEL_TRACES_TAIL_SAMPLING_MAX_TRACES", settings.MaxTracesCfg, defaultMaxBufferedTraces, 1, ErrInvalidSamplerConfig); err != nil {
+		return TailSampling{}, err
+	}
+	if tail.MaxSpans, err = parseInt("OTEL_TRACES_TAIL_SAMPLING_MAX_SPANS", settings.MaxSpansCfg, defaultMaxBufferedSpans, 1, ErrInvalidSamplerConfig); err != nil {
+		return TailSampling{}, err
+	}
+
+	if settings.RatioCfg != "" {
+		tail.Ratio, err = strconv.ParseFloat(settings.RatioCfg, 64)
+		if err != nil || tail.Ratio < 0 || tail.Ratio > 1 {
+			return TailSampling{}, fmt.Errorf("%w: OTEL_TRACES_TAIL_SAMPLING_RATIO must be a number between 0 and 1, got %q", ErrInvalidSamplerConfig, settings.RatioCfg)
+		}
+	}
+
+	for _, pair := range strings.Split(settings.AttributesCfg, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return TailSampling{}, fmt.Errorf("%w: OTEL_TRACES_TAIL_SAMPLING_ATTRIBUTES contains an invalid attribute %q", ErrInvalidSamplerConfig, pair)
+		}
+		if tail.Attributes == nil {
+			tail.Attributes = map[string]string{}
+		}
+		tail.Attributes[key] = strings.TrimSpace(value)
+	}
+
+	return tail, nil
+}
@@ -0,0 +1,122 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracesTailSampling(t *testing.T) {
+	tests := []struct {
+		name         string
+		variables    map[string]string
+		expectedTail TailSampling
+		expectedErr  error
+	}{
+		{
+			name:      "with default settings",
+			variables: map[string]string{},
+			expectedTail: TailSampling{
+				DecisionWait: 10 * time.Second,
+				Ratio:        0.1,
+				MaxTraces:    10000,
+				MaxSpans:     100000,
+			},
+		},
+		{
+			name: "with custom settings",
+			variables: map[string]string{
+				"OTEL_TRACES_TAIL_SAMPLING_ENABLED":           "true",
+				"OTEL_TRACES_TAIL_SAMPLING_DECISION_WAIT":     "5000",
+				"OTEL_TRACES_TAIL_SAMPLING_LATENCY_THRESHOLD": "2000",
+				"OTEL_TRACES_TAIL_SAMPLING_ATTRIBUTES":        "debug=true, tenant = acme",
+				"OTEL_TRACES_TAIL_SAMPLING_RATIO":             "0.25",
+				"OTEL_TRACES_TAIL_SAMPLING_MAX_TRACES":        "100",
+				"OTEL_TRACES_TAIL_SAMPLING_MAX_SPANS":         "1000",
+			},
+			expectedTail: TailSampling{
+				Enabled:          true,
+				DecisionWait:     5 * time.Second,
+				LatencyThreshold: 2 * time.Second,
+				Attributes:       map[string]string{"debug": "true", "tenant": "acme"},
+				Ratio:            0.25,
+				MaxTraces:        100,
+				MaxSpans:         1000,
+			},
+		},
+		{
+			name: "with zero decision wait",
+			variables: map[string]string{
+				"OTEL_TRACES_TAIL_SAMPLING_DECISION_WAIT": "0",
+			},
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+		{
+			name: "with negative latency threshold",
+			variables: map[string]string{
+				"OTEL_TRACES_TAIL_SAMPLING_LATENCY_THRESHOLD": "-1",
+			},
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+		{
+			name: "with ratio out of range",
+			variables: map[string]string{
+				"OTEL_TRACES_TAIL_SAMPLING_RATIO": "1.5",
+			},
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+		{
+			name: "with invalid attribute",
+			variables: map[string]string{
+				"OTEL_TRACES_TAIL_SAMPLING_ATTRIBUTES": "debug",
+			},
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+		{
+			name: "with invalid max traces",
+			variables: map[string]string{
+				"OTEL_TRACES_TAIL_SAMPLING_MAX_TRACES": "0",
+			},
+			expectedErr: ErrInvalidSamplerConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseMonitoringConfig(WithEnvironment(tt.variables))
+			require.NoError(t, err)
+
+			tail, err := cfg.TracesTailSampling()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTail, tail)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package credentials // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	grpcCredentials "google.golang.org/grpc/credentials"
+)
+
+const (
+	// DefaultRefreshInterval is the default interval after which the headers of a file are read again.
+	DefaultRefreshInterval = 30 * time.Second
+	// defaultHTTPTimeout is the default timeout of the OTLP/HTTP exports, as in the OTLP exporters.
+	defaultHTTPTimeout = 10 * time.Second
+)
+
+// HeaderProvider provides the headers which are sent with every export request of the OTLP exporters.
+//
+// It is called before every export request, so it should cache the headers
+// (see NewRefreshingHeaderProvider).
+type HeaderProvider interface {
+	Headers(ctx context.Context) (map[string]string, error)
+}
+
+// HeaderProviderFunc is a function that implements the HeaderProvider interface.
+type HeaderProviderFunc func(ctx context.Context) (map[string]string, error)
+
+// Headers calls the function.
+func (f HeaderProviderFunc) Headers(ctx context.Context) (map[string]string, error) {
+	return f(ctx)
+}
+
+// refreshingProvider caches the headers of a fetch function for a refresh interval.
+type refreshingProvider struct {
+	fetch    HeaderProviderFunc
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	headers   map[string]string
+	fetchedAt time.Time
+	loaded    bool
+}
+
+// NewRefreshingHeaderProvider returns a HeaderProvider that calls fetch at most once per refresh interval,
+// and caches its headers in between (e.g. to refresh short-lived tokens).
+//
+// If fetch fails after the headers were loaded once, the last headers are kept until the next refresh.
+func NewRefreshingHeaderProvider(fetch HeaderProviderFunc, interval time.Duration) HeaderProvider {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	return &refreshingProvider{
+		fetch:    fetch,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Headers returns the cached headers, or fetches them if the refresh interval has elapsed.
+func (p *refreshingProvider) Headers(ctx context.Context) (map[string]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.loaded && now.Sub(p.fetchedAt) < p.interval {
+		return p.headers, nil
+	}
+
+	headers, err := p.fetch(ctx)
+	if err != nil {
+		if p.loaded {
+			// retry on the next refresh, and keep the last headers in between
+			p.fetchedAt = now
+			return p.headers, nil
+		}
+		return nil, err
+	}
+
+	p.headers = headers
+	p.fetchedAt = now
+	p.loaded = true
+
+	return headers, nil
+}
+
+// NewFileHeaderProvider returns a HeaderProvider that reads the headers from a file (e.g. a mounted Kubernetes secret),
+// and reads it again once the refresh interval has elapsed.
+//
+// The file contains key=value pairs (with URL encoded values), separated by commas or new lines,
+// like the `OTEL_EXPORTER_OTLP_HEADERS` variable.
+func NewFileHeaderProvider(path string, interval time.Duration) HeaderProvider {
+	return NewRefreshingHeaderProvider(func(context.Context) (map[string]string, error) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		headers, err := ParseHeaders(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s contains an %w", path, err)
+		}
+		return headers, nil
+	}, interval)
+}
+
+// ParseHeaders parses a W3C Baggage-like list of key=value pairs, where values are URL encoded.
+// The pairs are separated by commas or new lines.
+func ParseHeaders(value string) (map[string]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	headers := map[string]string{}
+	for _, pair := range strings.Split(strings.ReplaceAll(value, "\n", ","), ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid header %q", pair)
+		}
+
+		decoded, err := url.PathUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid header value for %q", k)
+		}
+		headers[k] = decoded
+	}
+
+	return headers, nil
+}
+
+// perRPCCredentials adds the headers of a HeaderProvider to every gRPC request.
+type perRPCCredentials struct {
+	provider   HeaderProvider
+	requireTLS bool
+}
+
+// GetRequestMetadata returns the headers of the provider.
+func (c perRPCCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	return c.provider.Headers(ctx)
+}
+
+// RequireTransportSecurity returns whether the headers can only be sent over TLS.
+func (c perRPCCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
+
+// NewPerRPCCredentials returns the gRPC credentials that add the headers of the provider to every request.
+// If the connection is insecure, requireTLS must be false.
+func NewPerRPCCredentials(provider HeaderProvider, requireTLS bool) grpcCredentials.PerRPCCredentials {
+	return perRPCCredentials{provider: provider, requireTLS: requireTLS}
+}
+
+// transport adds the headers of a HeaderProvider to every HTTP request.
+type transport struct {
+	provider HeaderProvider
+	base     http.RoundTripper
+}
+
+// RoundTrip sets the headers of the provider on a copy of the request, and sends it.
+func (t transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	headers, err := t.provider.Headers(r.Context())
+	if err != nil {
+		if r.Body != nil {
+			_ = r.Body.Close()
+		}
+		return nil, err
+	}
+
+	r = r.Clone(r.Context())
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+
+	return t.base.RoundTrip(r)
+}
+
+// NewHTTPClient returns the HTTP client of the OTLP/HTTP exporters, which adds the headers of the provider
+// to every request. It replaces the client of the exporters, so it is also given their TLS configuration
+// and timeout (10 seconds, if the timeout is not positive).
+func NewHTTPClient(provider HeaderProvider, tlsConfig *tls.Config, timeout time.Duration) *http.Client {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		base.TLSClientConfig = tlsConfig
+	}
+
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	return &http.Client{
+		Transport: transport{provider: provider, base: base},
+		Timeout:   timeout,
+	}
+}
@@ -0,0 +1,194 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package credentials // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name            string
+		value           string
+		expectedHeaders map[string]string
+		expectedError   string
+	}{
+		{
+			name:  "with empty value",
+			value: " \n",
+		},
+		{
+			name:            "with comma separated pairs",
+			value:           "api-key=secret, tenant=a%20b",
+			expectedHeaders: map[string]string{"api-key": "secret", "tenant": "a b"},
+		},
+		{
+			name:            "with one pair per line",
+			value:           "api-key=secret\r\ntenant=a\n",
+			expectedHeaders: map[string]string{"api-key": "secret", "tenant": "a"},
+		},
+		{
+			name:          "with invalid pair",
+			value:         "api-key",
+			expectedError: `invalid header "api-key"`,
+		},
+		{
+			name:          "with invalid value",
+			value:         "api-key=%zz",
+			expectedError: `invalid header value for "api-key"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, err := ParseHeaders(tt.value)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHeaders, headers)
+		})
+	}
+}
+
+func TestRefreshingHeaderProvider(t *testing.T) {
+	ctx := context.Background()
+
+	calls := 0
+	var fetchErr error
+	provider := NewRefreshingHeaderProvider(func(context.Context) (map[string]string, error) {
+		calls++
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		return map[string]string{"authorization": "token-" + strconv.Itoa(calls)}, nil
+	}, time.Minute).(*refreshingProvider)
+
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	headers, err := provider.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "token-1"}, headers)
+
+	// cached within the refresh interval
+	now = now.Add(30 * time.Second)
+	headers, err = provider.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "token-1"}, headers)
+	assert.Equal(t, 1, calls)
+
+	// refreshed once the refresh interval has elapsed
+	now = now.Add(time.Minute)
+	headers, err = provider.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "token-2"}, headers)
+
+	// the last headers are kept if the refresh fails
+	fetchErr = errors.New("unavailable")
+	now = now.Add(time.Minute)
+	headers, err = provider.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "token-2"}, headers)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRefreshingHeaderProviderFailsBeforeLoading(t *testing.T) {
+	provider := NewRefreshingHeaderProvider(func(context.Context) (map[string]string, error) {
+		return nil, errors.New("unavailable")
+	}, time.Minute)
+
+	_, err := provider.Headers(context.Background())
+	require.EqualError(t, err, "unavailable")
+}
+
+func TestFileHeaderProvider(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "headers")
+	require.NoError(t, os.WriteFile(path, []byte("api-key=first\n"), 0o600))
+
+	provider := NewFileHeaderProvider(path, time.Millisecond)
+
+	headers, err := provider.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"api-key": "first"}, headers)
+
+	require.NoError(t, os.WriteFile(path, []byte("api-key=second\n"), 0o600))
+	time.Sleep(2 * time.Millisecond)
+
+	headers, err = provider.Headers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"api-key": "second"}, headers)
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header
+	}))
+	t.Cleanup(server.Close)
+
+	provider := HeaderProviderFunc(func(context.Context) (map[string]string, error) {
+		return map[string]string{"Api-Key": "secret"}, nil
+	})
+
+	client := NewHTTPClient(provider, nil, 0)
+	assert.Equal(t, defaultHTTPTimeout, client.Timeout)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, "secret", received.Get("Api-Key"))
+	// the original request is not modified
+	assert.Empty(t, req.Header.Get("Api-Key"))
+}
+
+func TestNewPerRPCCredentials(t *testing.T) {
+	provider := HeaderProviderFunc(func(context.Context) (map[string]string, error) {
+		return map[string]string{"api-key": "secret"}, nil
+	})
+
+	creds := NewPerRPCCredentials(provider, true)
+
+	metadata, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"api-key": "secret"}, metadata)
+	assert.True(t, creds.RequireTransportSecurity())
+	assert.False(t, NewPerRPCCredentials(provider, false).RequireTransportSecurity())
+}
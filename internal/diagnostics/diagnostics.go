@@ -0,0 +1,166 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diagnostics // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/diagnostics"
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Signal is a telemetry signal.
+type Signal string
+
+const (
+	// SignalTraces is the traces signal.
+	SignalTraces Signal = "traces"
+	// SignalMetrics is the metrics signal.
+	SignalMetrics Signal = "metrics"
+)
+
+// State is the state of the exporter of a signal.
+type State struct {
+	// Exporter is the type of the exporter (e.g. otlp/grpc, stdout or none).
+	Exporter string
+	// Resource holds the resource attributes attached to the telemetry.
+	Resource map[string]string
+	// LastSuccess is the time of the last successful export.
+	LastSuccess time.Time
+	// LastFailure is the time of the last failed export.
+	LastFailure time.Time
+	// LastError is the error of the last failed export.
+	LastError string
+}
+
+var (
+	mu     sync.RWMutex
+	states = map[Signal]State{}
+)
+
+// Reset clears the state of all the signals.
+// This function is primarily intended for testing purposes.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	states = map[Signal]State{}
+}
+
+// SetExporter records the exporter type and the resource of the signal,
+// and clears the results of the previous exports.
+func SetExporter(signal Signal, exporter string, res *resource.Resource) {
+	state := State{Exporter: exporter}
+	if res != nil {
+		state.Resource = make(map[string]string, res.Len())
+		for _, kv := range res.Attributes() {
+			state.Resource[string(kv.Key)] = kv.Value.Emit()
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	states[signal] = state
+}
+
+// RecordExport records the result of an export of the signal.
+func RecordExport(signal Signal, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	state := states[signal]
+	if err != nil {
+		state.LastFailure = time.Now()
+		state.LastError = err.Error()
+	} else {
+		state.LastSuccess = time.Now()
+	}
+	states[signal] = state
+}
+
+// Get returns the state of the signal.
+// The second return value is false if the signal has not been started.
+func Get(signal Signal) (State, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	state, ok := states[signal]
+	return state, ok
+}
+
+// spanExporter records the result of every export of the wrapped exporter.
+type spanExporter struct {
+	sdktrace.SpanExporter
+}
+
+// WrapSpanExporter returns a SpanExporter that records the result of every export.
+func WrapSpanExporter(exporter sdktrace.SpanExporter) sdktrace.SpanExporter {
+	return spanExporter{SpanExporter: exporter}
+}
+
+// ExportSpans exports the spans and records the result.
+func (e spanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	RecordExport(SignalTraces, err)
+	return err
+}
+
+// metricExporter records the result of every export of the wrapped exporter.
+type metricExporter struct {
+	sdkmetric.Exporter
+}
+
+// WrapMetricExporter returns a metric Exporter that records the result of every export.
+func WrapMetricExporter(exporter sdkmetric.Exporter) sdkmetric.Exporter {
+	return metricExporter{Exporter: exporter}
+}
+
+// Export exports the metrics and records the result.
+func (e metricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	err := e.Exporter.Export(ctx, rm)
+	RecordExport(SignalMetrics, err)
+	return err
+}
+
+// ExporterName returns the description of the exporters created for the given types and OTLP protocol
+// (e.g. "otlp/grpc,stdout").
+func ExporterName(types []string, protocol string) string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		switch t {
+		case "otlp":
+			names = append(names, "otlp/"+protocol)
+		case "console":
+			names = append(names, "stdout")
+		default:
+			names = append(names, t)
+		}
+	}
+	return strings.Join(names, ",")
+}
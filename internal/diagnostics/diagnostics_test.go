@@ -0,0 +1,95 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// failingMetricExporter is a metric exporter whose exports always fail.
+type failingMetricExporter struct {
+	sdkmetric.Exporter
+}
+
+func (failingMetricExporter) Export(context.Context, *metricdata.ResourceMetrics) error {
+	return errors.New("unavailable")
+}
+
+func TestSetExporter(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+
+	_, ok := Get(SignalTraces)
+	assert.False(t, ok)
+
+	SetExporter(SignalTraces, "otlp/grpc", resource.NewSchemaless(attribute.String("service.name", "test-service")))
+	RecordExport(SignalTraces, nil)
+
+	state, ok := Get(SignalTraces)
+	require.True(t, ok)
+	assert.Equal(t, "otlp/grpc", state.Exporter)
+	assert.Equal(t, map[string]string{"service.name": "test-service"}, state.Resource)
+	assert.False(t, state.LastSuccess.IsZero())
+
+	// setting the exporter again clears the previous results
+	SetExporter(SignalTraces, "stdout", nil)
+	state, _ = Get(SignalTraces)
+	assert.True(t, state.LastSuccess.IsZero())
+}
+
+func TestWrapExporters(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+
+	spans := WrapSpanExporter(tracetest.NewInMemoryExporter())
+	require.NoError(t, spans.ExportSpans(context.Background(), nil))
+
+	metrics := WrapMetricExporter(failingMetricExporter{})
+	require.Error(t, metrics.Export(context.Background(), &metricdata.ResourceMetrics{}))
+
+	traces, _ := Get(SignalTraces)
+	assert.False(t, traces.LastSuccess.IsZero())
+	assert.True(t, traces.LastFailure.IsZero())
+
+	state, _ := Get(SignalMetrics)
+	assert.True(t, state.LastSuccess.IsZero())
+	assert.False(t, state.LastFailure.IsZero())
+	assert.Equal(t, "unavailable", state.LastError)
+}
+
+func TestExporterName(t *testing.T) {
+	assert.Equal(t, "stdout", ExporterName([]string{"console"}, "grpc"))
+	assert.Equal(t, "otlp/http/protobuf", ExporterName([]string{"otlp"}, "http/protobuf"))
+	assert.Equal(t, "otlp/grpc,stdout", ExporterName([]string{"otlp", "console"}, "grpc"))
+	assert.Equal(t, "none", ExporterName([]string{"none"}, "grpc"))
+}
@@ -0,0 +1,232 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package fileexporter // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/fileexporter"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// idKeys are the JSON keys of the trace and span ids, which OTLP JSON encodes in hex instead of base64.
+var idKeys = [][]byte{
+	[]byte(`"traceId`),
+	[]byte(`"spanId`),
+	[]byte(`"parentSpanId`),
+}
+
+// idKeyEnd is the end of the JSON keys of the ids, up to the opening quote of their value.
+var idKeyEnd = []byte(`Id":"`)
+
+// NewSpanExporter returns an exporter which writes every batch of spans to the file as a line of OTLP JSON,
+// i.e. an ExportTraceServiceRequest.
+func NewSpanExporter(ctx context.Context, cfg config.FileExporter) (sdktrace.SpanExporter, error) {
+	return otlptrace.New(ctx, traceClient{writer: NewWriter(cfg)})
+}
+
+// NewMetricExporter returns an exporter which writes every collection of metrics to the file as a line of OTLP JSON,
+// i.e. an ExportMetricsServiceRequest.
+func NewMetricExporter(ctx context.Context, cfg config.FileExporter) (sdkmetric.Exporter, error) {
+	writer := NewWriter(cfg)
+
+	// the OTLP/HTTP exporter converts the metrics to OTLP, and its requests are written to the file instead of being sent.
+	// The options override the OTEL_EXPORTER_OTLP_* variables of the OTLP exporter, which are read from the environment.
+	exporter, err := otlpmetrichttp.New(ctx,
+		otlpmetrichttp.WithEndpointURL("http://localhost/v1/metrics"),
+		otlpmetrichttp.WithHTTPClient(&http.Client{Transport: metricTransport{writer: writer}}),
+		otlpmetrichttp.WithCompression(otlpmetrichttp.NoCompression),
+		otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{Enabled: false}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return metricExporter{Exporter: exporter, writer: writer}, nil
+}
+
+// traceClient is an OTLP trace client which writes the spans to the file.
+type traceClient struct {
+	writer *Writer
+}
+
+// Start does nothing, as the file is opened on the first write.
+func (c traceClient) Start(context.Context) error {
+	return nil
+}
+
+// Stop closes the file.
+func (c traceClient) Stop(context.Context) error {
+	return c.writer.Close()
+}
+
+// UploadTraces writes the spans to the file.
+func (c traceClient) UploadTraces(_ context.Context, spans []*tracepb.ResourceSpans) error {
+	line, err := marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: spans})
+	if err != nil {
+		return err
+	}
+	return c.writer.WriteLine(line)
+}
+
+// metricTransport is an HTTP transport which writes the OTLP/HTTP metric requests to the file.
+type metricTransport struct {
+	writer *Writer
+}
+
+// RoundTrip writes the metrics of the request to the file, and responds with an empty OTLP response.
+func (t metricTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &colmetricpb.ExportMetricsServiceRequest{}
+	if err := proto.Unmarshal(body, request); err != nil {
+		return nil, err
+	}
+	line, err := marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.writer.WriteLine(line); err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      r.Proto,
+		ProtoMajor: r.ProtoMajor,
+		ProtoMinor: r.ProtoMinor,
+		Header:     http.Header{"Content-Type": []string{"application/x-protobuf"}},
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+		Request:    r,
+	}, nil
+}
+
+// readBody reads and closes the body of the request, decompressing it if it is gzip encoded.
+func readBody(r *http.Request) ([]byte, error) {
+	defer func() { _ = r.Body.Close() }()
+
+	var body io.Reader = r.Body
+	switch encoding := r.Header.Get("Content-Encoding"); encoding {
+	case "":
+	case "gzip":
+		reader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = reader.Close() }()
+		body = reader
+	default:
+		return nil, errors.New("unsupported content encoding " + encoding)
+	}
+
+	return io.ReadAll(body)
+}
+
+// metricExporter closes the file when the exporter is shut down.
+type metricExporter struct {
+	sdkmetric.Exporter
+	writer *Writer
+}
+
+// Shutdown shuts down the exporter, and closes the file.
+func (e metricExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.Exporter.Shutdown(ctx), e.writer.Close())
+}
+
+// marshal encodes the request as a single line of OTLP JSON: the enums are numbers, and the trace and span ids are hex encoded.
+func marshal(request proto.Message) ([]byte, error) {
+	encoded, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// protojson may add spaces between the keys and the values
+	compact := bytes.NewBuffer(make([]byte, 0, len(encoded)))
+	if err := json.Compact(compact, encoded); err != nil {
+		return nil, err
+	}
+
+	return hexIDs(compact.Bytes())
+}
+
+// hexIDs replaces the base64 trace and span ids of the compact JSON with their hex encoding.
+//
+// A key can't be mistaken for a string value, as the quotes are escaped in the string values.
+func hexIDs(encoded []byte) ([]byte, error) {
+	result := make([]byte, 0, len(encoded)+len(encoded)/8)
+
+	for {
+		i := bytes.Index(encoded, idKeyEnd)
+		if i < 0 {
+			return append(result, encoded...), nil
+		}
+
+		start := i + len(idKeyEnd)
+		result = append(result, encoded[:start]...)
+		if !isIDKey(encoded[:i+len("Id")]) {
+			encoded = encoded[start:]
+			continue
+		}
+
+		end := bytes.IndexByte(encoded[start:], '"')
+		if end < 0 {
+			return nil, errors.New("unterminated id in the OTLP JSON")
+		}
+		id, err := base64.StdEncoding.AppendDecode(nil, encoded[start:start+end])
+		if err != nil {
+			return nil, err
+		}
+		result = hex.AppendEncode(result, id)
+		encoded = encoded[start+end:]
+	}
+}
+
+// isIDKey returns whether the JSON ends with one of the keys of the ids.
+func isIDKey(encoded []byte) bool {
+	for _, key := range idKeys {
+		if bytes.HasSuffix(encoded, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package fileexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSpanExporter(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+
+	exporter, err := NewSpanExporter(ctx, config.FileExporter{Path: path, MaxSize: 1 << 20})
+	require.NoError(t, err)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	parentCtx, parent := tp.Tracer("test").Start(ctx, "parent", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tp.Tracer("test").Start(parentCtx, "child", trace.WithAttributes(attribute.String("key", "value")))
+	child.End()
+	parent.End()
+	require.NoError(t, tp.Shutdown(ctx))
+
+	lines := readLines(t, path)
+	require.Len(t, lines, 2)
+
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string `json:"traceId"`
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+					Kind         int    `json:"kind"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &request))
+	span := request.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "child", span.Name)
+	assert.Equal(t, child.SpanContext().TraceID().String(), span.TraceID)
+	assert.Equal(t, child.SpanContext().SpanID().String(), span.SpanID)
+	assert.Equal(t, parent.SpanContext().SpanID().String(), span.ParentSpanID)
+	assert.Equal(t, 1, span.Kind)
+}
+
+func TestMetricExporter(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables map[string]string
+	}{
+		{
+			name: "without compression",
+		},
+		{
+			// the OTLP exporter reads its settings from the environment, but the metrics are written uncompressed
+			name: "with gzip compression in the environment",
+			variables: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION":         "gzip",
+				"OTEL_EXPORTER_OTLP_METRICS_COMPRESSION": "gzip",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.variables {
+				t.Setenv(key, value)
+			}
+
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "metrics.jsonl")
+
+			exporter, err := NewMetricExporter(ctx, config.FileExporter{Path: path, MaxSize: 1 << 20})
+			require.NoError(t, err)
+
+			now := time.Now()
+			require.NoError(t, exporter.Export(ctx, &metricdata.ResourceMetrics{
+				Resource: resource.Empty(),
+				ScopeMetrics: []metricdata.ScopeMetrics{{
+					Scope: instrumentation.Scope{Name: "test"},
+					Metrics: []metricdata.Metrics{{
+						Name: "requests",
+						Data: metricdata.Sum[int64]{
+							Temporality: metricdata.CumulativeTemporality,
+							IsMonotonic: true,
+							DataPoints:  []metricdata.DataPoint[int64]{{StartTime: now, Time: now, Value: 3}},
+						},
+					}},
+				}},
+			}))
+			require.NoError(t, exporter.Shutdown(ctx))
+
+			lines := readLines(t, path)
+			require.Len(t, lines, 1)
+
+			var request struct {
+				ResourceMetrics []struct {
+					ScopeMetrics []struct {
+						Metrics []struct {
+							Name string `json:"name"`
+							Sum  struct {
+								DataPoints []struct {
+									AsInt string `json:"asInt"`
+								} `json:"dataPoints"`
+								AggregationTemporality int `json:"aggregationTemporality"`
+							} `json:"sum"`
+						} `json:"metrics"`
+					} `json:"scopeMetrics"`
+				} `json:"resourceMetrics"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(lines[0]), &request))
+			metric := request.ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+			assert.Equal(t, "requests", metric.Name)
+			assert.Equal(t, "3", metric.Sum.DataPoints[0].AsInt)
+			assert.Equal(t, 2, metric.Sum.AggregationTemporality)
+		})
+	}
+}
+
+func TestMetricTransportGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	writer := NewWriter(config.FileExporter{Path: path, MaxSize: 1 << 20})
+	defer func() { require.NoError(t, writer.Close()) }()
+
+	body, err := proto.Marshal(&colmetricpb.ExportMetricsServiceRequest{})
+	require.NoError(t, err)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err = gz.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	r, err := http.NewRequest(http.MethodPost, "http://localhost/v1/metrics", &compressed)
+	require.NoError(t, err)
+	r.Header.Set("Content-Encoding", "gzip")
+
+	res, err := metricTransport{writer: writer}.RoundTrip(r)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, []string{"{}"}, readLines(t, path))
+}
+
+func TestHexIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoded  string
+		expected string
+	}{
+		{
+			name:     "with ids",
+			encoded:  `{"traceId":"AAECAwQFBgcICQoLDA0ODw==","spanId":"AAECAwQFBgc=","parentSpanId":"BwYFBAMCAQA="}`,
+			expected: `{"traceId":"000102030405060708090a0b0c0d0e0f","spanId":"0001020304050607","parentSpanId":"0706050403020100"}`,
+		},
+		{
+			name:     "with other keys ending with Id",
+			encoded:  `{"requestId":"AAECAwQFBgc=","spanId":"AAECAwQFBgc="}`,
+			expected: `{"requestId":"AAECAwQFBgc=","spanId":"0001020304050607"}`,
+		},
+		{
+			name:     "with an id key in a string value",
+			encoded:  `{"key":"{\"spanId\":\"x\"}"}`,
+			expected: `{"key":"{\"spanId\":\"x\"}"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := hexIDs([]byte(tt.encoded))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result))
+		})
+	}
+}
+
+// readLines returns the lines of the file, without the trailing newline.
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	content := readFile(t, path)
+	require.NotEmpty(t, content)
+
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
@@ -0,0 +1,139 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package fileexporter // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/fileexporter"
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+)
+
+// Writer writes lines to a file, which is rotated when the next line would exceed its maximum size.
+//
+// The file is opened on the first write, and the lines are appended to an existing file.
+type Writer struct {
+	mu   sync.Mutex
+	cfg  config.FileExporter
+	file *os.File
+	size int64
+}
+
+// NewWriter returns a Writer of the file given in the configuration.
+func NewWriter(cfg config.FileExporter) *Writer {
+	return &Writer{cfg: cfg}
+}
+
+// WriteLine writes the line followed by a newline.
+func (w *Writer) WriteLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+	if w.size > 0 && w.size+int64(len(line))+1 > w.cfg.MaxSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.file.Write(append(line, '\n'))
+	w.size += int64(n)
+	return err
+}
+
+// Close closes the file. The next write opens it again.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// open opens the file for appending, and creates it with its directory if needed.
+func (w *Writer) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.cfg.Path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(w.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+
+	w.file, w.size = file, info.Size()
+	return nil
+}
+
+// rotate renames the file to <path>.1, after shifting the previous backups, and opens a new file.
+// The oldest backup is removed once there are more than the maximum number of backups.
+func (w *Writer) rotate() error {
+	err := w.file.Close()
+	w.file = nil
+	if err != nil {
+		return err
+	}
+
+	if w.cfg.MaxBackups == 0 {
+		if err := os.Remove(w.cfg.Path); err != nil {
+			return err
+		}
+		return w.open()
+	}
+
+	if err := os.Remove(w.backup(w.cfg.MaxBackups)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for i := w.cfg.MaxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(w.backup(i), w.backup(i+1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Rename(w.cfg.Path, w.backup(1)); err != nil {
+		return err
+	}
+
+	return w.open()
+}
+
+// backup returns the path of the i-th backup, the first being the newest.
+func (w *Writer) backup(i int) string {
+	return w.cfg.Path + "." + strconv.Itoa(i)
+}
@@ -0,0 +1,83 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package fileexporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readFile returns the content of the file.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestWriter(t *testing.T) {
+	t.Run("rotates the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "telemetry", "traces.jsonl")
+		w := NewWriter(config.FileExporter{Path: path, MaxSize: 10, MaxBackups: 2})
+
+		for _, line := range []string{"first", "second", "third", "fourth"} {
+			require.NoError(t, w.WriteLine([]byte(line)))
+		}
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "fourth\n", readFile(t, path))
+		assert.Equal(t, "third\n", readFile(t, path+".1"))
+		assert.Equal(t, "second\n", readFile(t, path+".2"))
+		assert.NoFileExists(t, path+".3")
+	})
+
+	t.Run("without backups", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "traces.jsonl")
+		w := NewWriter(config.FileExporter{Path: path, MaxSize: 10, MaxBackups: 0})
+
+		require.NoError(t, w.WriteLine([]byte("first")))
+		require.NoError(t, w.WriteLine([]byte("second")))
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "second\n", readFile(t, path))
+		assert.NoFileExists(t, path+".1")
+	})
+
+	t.Run("appends to an existing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "traces.jsonl")
+		require.NoError(t, os.WriteFile(path, []byte("previous\n"), 0o600))
+
+		w := NewWriter(config.FileExporter{Path: path, MaxSize: 1 << 20, MaxBackups: 1})
+		require.NoError(t, w.WriteLine([]byte("next")))
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, []string{"previous", "next", ""}, strings.Split(readFile(t, path), "\n"))
+	})
+}
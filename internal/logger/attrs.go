@@ -28,34 +28,35 @@ import (
 	"time"
 
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
-	"go.opentelemetry.io/otel/sdk/resource"
+	internalResource "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
 )
 
 // InjectRootAttrs adds root attributes to a slog handler.
 //
 // This function enhances the given slog.Handler with a set of attributes
 // that are common across the application, such as service instance ID,
-// and service version The attributes are being fetched from the environment
-// variable "OTEL_RESOURCE_ATTRIBUTES". The goal is to have a consistent
-// set of attributes across all logs and spans. These attributes are typically added once at
+// and service version. The attributes are taken from the resource which is shared
+// with the traces and the metrics (see the internal resource package), so the
+// correlation keys are identical across all logs, spans and metrics.
+// If the configuration enables the resource attributes, every attribute of the resource
+// (e.g. deployment.environment, k8s.*) is added. These attributes are added once at
 // the root level and included in all logs generated by the handler.
 // InjectRootAttrs returns a new slog.Handler with the additional attributes applied.
 func InjectRootAttrs(h slog.Handler, cfg config.LoggerConfig) slog.Handler {
-	resourceInfo, _ := resource.New(
-		context.Background(),
-		resource.WithFromEnv(),
-	)
+	resourceInfo, _ := internalResource.Get(context.Background(), cfg.Service())
+
 	logAttributes := make([]slog.Attr, 0, 3)
 	logAttributes = append(logAttributes, slog.String(config.SERVICE_NAME, cfg.Service()))
 
 	attributes := resourceInfo.Attributes()
 	for _, attr := range attributes {
-		if string(attr.Key) == config.SERVICE_INTANCE_ID {
-			logAttributes = append(logAttributes, slog.String(config.SERVICE_INTANCE_ID, attr.Value.AsString()))
+		key := string(attr.Key)
+		if key == config.SERVICE_NAME {
+			continue
 		}
 
-		if string(attr.Key) == config.SERVICE_VERSION {
-			logAttributes = append(logAttributes, slog.String(config.SERVICE_VERSION, attr.Value.AsString()))
+		if cfg.ResourceAttributes() || key == config.SERVICE_INTANCE_ID || key == config.SERVICE_VERSION {
+			logAttributes = append(logAttributes, slog.Any(key, attr.Value.AsInterface()))
 		}
 	}
 
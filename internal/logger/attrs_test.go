@@ -31,6 +31,7 @@ import (
 	"time"
 
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	internalResource "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -75,6 +76,8 @@ func (lo *logOutput) Write(p []byte) (n int, err error) {
 func TestInjectRootAttrs(t *testing.T) {
 	_ = os.Setenv("OTEL_RESOURCE_ATTRIBUTES", "k8s.container.name={some-container},k8s.deployment.name={some-deployment},k8s.deployment.uid={some-uid},k8s.namespace.name={some-namespace},k8s.node.name={some-node},k8s.pod.name={some-pod},k8s.pod.uid={some-uid},k8s.replicaset.name={some-replicaset},k8s.replicaset.uid={some-uid},service.instance.id={some-namespace}.{some-pod}.{some-container},service.version={some-version}")
 	defer func() { _ = os.Unsetenv("OTEL_RESOURCE_ATTRIBUTES") }()
+	internalResource.Reset()
+	t.Cleanup(internalResource.Reset)
 
 	cfg := getLoggingConfig()
 	output := &logOutput{}
@@ -92,6 +95,18 @@ func TestInjectRootAttrs(t *testing.T) {
 	assert.Contains(t, output.log, config.SERVICE_INTANCE_ID)
 	assert.Equal(t, "{some-namespace}.{some-pod}.{some-container}", output.log[config.SERVICE_INTANCE_ID])
 
+	// the other resource attributes are only added if enabled
+	assert.NotContains(t, output.log, "k8s.pod.name")
+
+	allAttrsCfg := config.NewLoggerConfig()
+	allAttrsCfg.ServiceCfg = cfg.Service()
+	allAttrsCfg.ResourceAttributesCfg = true
+	log = slog.New(InjectRootAttrs(handler, allAttrsCfg))
+	log.Info("Test log message")
+
+	assert.Equal(t, "{some-pod}", output.log["k8s.pod.name"])
+	assert.Equal(t, "{some-version}", output.log[config.SERVICE_VERSION])
+	assert.Equal(t, cfg.Service(), output.log[config.SERVICE_NAME])
 }
 
 func TestReplaceAttributes(t *testing.T) {
@@ -0,0 +1,143 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/logger"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	internalResource "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// maxStackDepth is the maximum number of frames included in the stack trace
+	maxStackDepth = 64
+)
+
+// ServiceContext identifies the service that reported an error in Google Cloud Error Reporting.
+type ServiceContext struct {
+	// Service is the name of the service
+	Service string
+	// Version is the version of the service
+	Version string
+}
+
+// NewServiceContext returns the ServiceContext of the application.
+//
+// The service name is taken from the configuration, and the version from the
+// "service.version" attribute of the shared resource (e.g. from the "OTEL_RESOURCE_ATTRIBUTES" environment variable).
+func NewServiceContext(cfg config.LoggerConfig) ServiceContext {
+	serviceContext := ServiceContext{Service: cfg.Service()}
+
+	resourceInfo, _ := internalResource.Get(context.Background(), cfg.Service())
+	if version, ok := resourceInfo.Set().Value(attribute.Key(config.SERVICE_VERSION)); ok {
+		serviceContext.Version = version.AsString()
+	}
+
+	return serviceContext
+}
+
+// ErrorReportingAttrs returns the attributes that turn an error log record into a
+// Google Cloud Error Reporting event.
+//
+// The attributes are the "@type" marker, the stack trace of the caller formatted like a panic trace,
+// the "serviceContext" and the "context.reportLocation" built from the caller.
+func ErrorReportingAttrs(err error, caller utils.Caller, serviceContext ServiceContext) []slog.Attr {
+	serviceAttrs := []any{slog.String("service", serviceContext.Service)}
+	if serviceContext.Version != "" {
+		serviceAttrs = append(serviceAttrs, slog.String("version", serviceContext.Version))
+	}
+
+	functionName := caller.FunctionName
+	if caller.Namespace != "" {
+		functionName = caller.Namespace + "." + caller.FunctionName
+	}
+
+	return []slog.Attr{
+		slog.String(config.ERROR_REPORTING_TYPE_KEY, config.ERROR_REPORTING_TYPE),
+		slog.String(config.ERROR_REPORTING_STACK_TRACE_KEY, formatStack(err, caller)),
+		slog.Group(config.ERROR_REPORTING_SERVICE_CONTEXT_KEY, serviceAttrs...),
+		slog.Group(config.ERROR_REPORTING_CONTEXT_KEY,
+			slog.Group("reportLocation",
+				slog.String("filePath", caller.FilePath),
+				slog.Int("lineNumber", caller.LineNumber),
+				slog.String("functionName", functionName),
+			),
+		),
+	}
+}
+
+// formatStack returns the current stack trace formatted like a Go panic trace,
+// with the error message as the first line.
+//
+// The frames above the caller (the frames of the logger itself) are omitted. If the caller
+// cannot be found in the stack, the whole stack is returned.
+func formatStack(err error, caller utils.Caller) string {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	collected := make([]runtime.Frame, 0, n)
+	start := 0
+	found := false
+	for {
+		frame, more := frames.Next()
+		if !found && frame.File == caller.FilePath && frame.Line == caller.LineNumber {
+			start = len(collected)
+			found = true
+		}
+		collected = append(collected, frame)
+
+		if !more {
+			break
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(err.Error())
+	builder.WriteString("\n\n")
+	builder.WriteString(goroutineHeader())
+	builder.WriteString("\n")
+	for _, frame := range collected[start:] {
+		fmt.Fprintf(&builder, "%s()\n\t%s:%d +0x%x\n", frame.Function, frame.File, frame.Line, frame.PC-frame.Entry)
+	}
+
+	return builder.String()
+}
+
+// goroutineHeader returns the header of the stack trace of the current goroutine (e.g. "goroutine 7 [running]:"),
+// as printed by runtime.Stack.
+func goroutineHeader() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	header, _, _ := bytes.Cut(buf, []byte("\n"))
+	return string(header)
+}
@@ -0,0 +1,90 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	internalResource "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServiceContext(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.version=1.2.3")
+	internalResource.Reset()
+	t.Cleanup(internalResource.Reset)
+
+	serviceContext := NewServiceContext(getLoggingConfig())
+
+	assert.Equal(t, "test-service", serviceContext.Service)
+	assert.Equal(t, "1.2.3", serviceContext.Version)
+}
+
+func TestErrorReportingAttrs(t *testing.T) {
+	// the caller and the stack trace must be retrieved from the same line
+	attrs := ErrorReportingAttrs(errors.New("test error"), utils.GetCallerName(1), ServiceContext{Service: "test-service", Version: "1.2.3"})
+	caller := utils.GetCallerName(1)
+	require.Len(t, attrs, 4)
+
+	assert.Equal(t, config.ERROR_REPORTING_TYPE_KEY, attrs[0].Key)
+	assert.Equal(t, config.ERROR_REPORTING_TYPE, attrs[0].Value.String())
+
+	t.Run("Stack trace starts at the caller", func(t *testing.T) {
+		stack := attrs[1].Value.String()
+		lines := strings.Split(stack, "\n")
+
+		assert.Equal(t, config.ERROR_REPORTING_STACK_TRACE_KEY, attrs[1].Key)
+		assert.Equal(t, "test error", lines[0])
+		assert.Regexp(t, `^goroutine \d+ \[running\]:$`, lines[2])
+		assert.NotEqual(t, "goroutine 1 [running]:", lines[2], "the test goroutine is not the main goroutine")
+		assert.Equal(t, "github.com/FLYR-Open-Source/flyr-lib-go/internal/logger.TestErrorReportingAttrs()", lines[3])
+		assert.Contains(t, lines[4], "errorreporting_test.go")
+		assert.True(t, strings.HasPrefix(lines[4], "\t"))
+	})
+
+	t.Run("Service context", func(t *testing.T) {
+		assert.Equal(t, config.ERROR_REPORTING_SERVICE_CONTEXT_KEY, attrs[2].Key)
+		assert.Equal(t, "[service=test-service version=1.2.3]", attrs[2].Value.String())
+	})
+
+	t.Run("Report location", func(t *testing.T) {
+		assert.Equal(t, config.ERROR_REPORTING_CONTEXT_KEY, attrs[3].Key)
+
+		location := attrs[3].Value.Group()[0]
+		assert.Equal(t, "reportLocation", location.Key)
+
+		values := map[string]slog.Value{}
+		for _, a := range location.Value.Group() {
+			values[a.Key] = a.Value
+		}
+		assert.Equal(t, caller.FilePath, values["filePath"].String())
+		assert.Equal(t, int64(caller.LineNumber-1), values["lineNumber"].Int64())
+		assert.Equal(t, caller.Namespace+"."+caller.FunctionName, values["functionName"].String())
+	})
+}
@@ -33,10 +33,11 @@ import (
 // This function initializes a slog.Handler that outputs logs in JSON format
 // to standard output. The handler is setting the log level according to the provided configuration,
 // and replacing certain attributes using the replaceAttributes function for
-// custom formatting.
-func NewJSONLogHandler(level slog.Level) slog.Handler {
+// custom formatting. Records larger than the maximum record size of the given
+// limits are shrunk before being written.
+func NewJSONLogHandler(level slog.Leveler, limits Limits) slog.Handler {
 	return slog.NewJSONHandler(
-		os.Stdout,
+		&recordSizeWriter{w: os.Stdout, limits: limits},
 		&slog.HandlerOptions{
 			AddSource:   false,
 			Level:       level,
@@ -0,0 +1,193 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/logger"
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	internalUtils "github.com/FLYR-Open-Source/flyr-lib-go/internal/utils"
+	slogmulti "github.com/samber/slog-multi"
+)
+
+// loggingPackages are the function prefixes of the frames between the caller and the handler.
+// They are skipped when the package of the caller is resolved.
+var loggingPackages = []string{
+	"runtime.",
+	"log/slog.",
+	"log.",
+	"github.com/samber/slog-multi",
+	"github.com/go-logr/logr",
+	"github.com/FLYR-Open-Source/flyr-lib-go/logger.",
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/logger.",
+}
+
+// packageLevel is the minimum level of the functions of a package and its sub-packages.
+type packageLevel struct {
+	pkg   string
+	level slog.Level
+}
+
+// Levels holds the global and the per-package minimum log levels.
+//
+// The levels can be changed at any time with Set, and the change applies to
+// every handler created with them.
+type Levels struct {
+	global   slog.LevelVar
+	minimum  slog.LevelVar
+	maximum  slog.LevelVar
+	packages atomic.Pointer[[]packageLevel]
+}
+
+// NewLevels returns the Levels of the given global level and per-package levels.
+func NewLevels(global string, packages map[string]string) *Levels {
+	l := &Levels{}
+	l.Set(global, packages)
+	return l
+}
+
+// Set changes the global level and the per-package levels.
+//
+// The per-package levels are given as package path to level name (e.g. debug).
+func (l *Levels) Set(global string, packages map[string]string) {
+	globalLevel := ParseLogLevel(global)
+	minimum, maximum := globalLevel, globalLevel
+
+	levels := make([]packageLevel, 0, len(packages))
+	for pkg, level := range packages {
+		pkgLevel := ParseLogLevel(level)
+		levels = append(levels, packageLevel{pkg: strings.TrimSuffix(pkg, "/"), level: pkgLevel})
+		minimum = min(minimum, pkgLevel)
+		maximum = max(maximum, pkgLevel)
+	}
+
+	// the most specific package is matched first
+	sort.Slice(levels, func(i, j int) bool {
+		return len(levels[i].pkg) > len(levels[j].pkg)
+	})
+
+	l.packages.Store(&levels)
+	l.global.Set(globalLevel)
+	l.minimum.Set(minimum)
+	l.maximum.Set(maximum)
+}
+
+// Level returns the lowest of the global and the per-package levels.
+//
+// It implements slog.Leveler, so the handlers let through every record that
+// could be enabled for any of the packages.
+func (l *Levels) Level() slog.Level {
+	return l.minimum.Level()
+}
+
+// enabled returns whether a record of the given level, logged by the given function, is enabled.
+func (l *Levels) enabled(level slog.Level, function string) bool {
+	for _, p := range *l.packages.Load() {
+		if function == p.pkg || strings.HasPrefix(function, p.pkg+".") || strings.HasPrefix(function, p.pkg+"/") {
+			return level >= p.level
+		}
+	}
+	return level >= l.global.Level()
+}
+
+// dependsOnPackage returns whether a record of the given level is enabled for some packages only,
+// so the package of the caller must be resolved.
+func (l *Levels) dependsOnPackage(level slog.Level) bool {
+	return level < l.maximum.Level() && len(*l.packages.Load()) > 0
+}
+
+// callerFunction returns the fully qualified name of the first function
+// in the call stack outside of the logging packages.
+func callerFunction() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !internalUtils.HasAnyPrefix(frame.Function, loggingPackages) {
+			return frame.Function
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// LevelHandler drops the records below the level of the package which logged them.
+type LevelHandler struct {
+	// next is the next handler in the chain
+	next slog.Handler
+	// levels are the global and per-package levels
+	levels *Levels
+}
+
+// Enabled returns true if the log level is greater than or equal to the lowest of the levels
+func (h *LevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.levels.Level()
+}
+
+// Handle passes the record to the next handler, if it is enabled for the package of the caller
+func (h *LevelHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level < h.levels.Level() {
+		return nil
+	}
+	// the caller is only resolved when the record passes some of the levels but not all of them
+	if h.levels.dependsOnPackage(record.Level) && !h.levels.enabled(record.Level, callerFunction()) {
+		return nil
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a new handler with the given attributes added to the log record
+func (h *LevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LevelHandler{
+		next:   h.next.WithAttrs(attrs),
+		levels: h.levels,
+	}
+}
+
+// WithGroup returns a new handler with the given group name added to the log record
+func (h *LevelHandler) WithGroup(name string) slog.Handler {
+	return &LevelHandler{
+		next:   h.next.WithGroup(name),
+		levels: h.levels,
+	}
+}
+
+// NewLevelHandler creates a new LevelHandler with the given levels.
+//
+// Returns an slogmulti.Middleware
+func NewLevelHandler(levels *Levels) slogmulti.Middleware {
+	return func(next slog.Handler) slog.Handler {
+		return &LevelHandler{
+			next:   next,
+			levels: levels,
+		}
+	}
+}
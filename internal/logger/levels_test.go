@@ -0,0 +1,116 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevels(t *testing.T) {
+	levels := NewLevels("warn", map[string]string{
+		"github.com/acme/service":    "info",
+		"github.com/acme/service/db": "debug",
+		"github.com/acme/noisy/":     "error",
+	})
+
+	assert.Equal(t, slog.LevelDebug, levels.Level())
+
+	tests := []struct {
+		name     string
+		level    slog.Level
+		function string
+		expected bool
+	}{
+		{"global level", slog.LevelInfo, "main.main", false},
+		{"global level warn", slog.LevelWarn, "main.main", true},
+		{"package level", slog.LevelInfo, "github.com/acme/service.Handle", true},
+		{"package level debug", slog.LevelDebug, "github.com/acme/service.Handle", false},
+		{"most specific package", slog.LevelDebug, "github.com/acme/service/db.(*Repo).Get", true},
+		{"sub-package", slog.LevelInfo, "github.com/acme/service/http.Serve", true},
+		{"package with trailing slash", slog.LevelWarn, "github.com/acme/noisy.Run", false},
+		{"package with the same prefix", slog.LevelInfo, "github.com/acme/service2.Run", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, levels.enabled(tt.level, tt.function))
+		})
+	}
+
+	t.Run("resolves the package only between the lowest and the highest level", func(t *testing.T) {
+		assert.True(t, levels.dependsOnPackage(slog.LevelDebug))
+		assert.True(t, levels.dependsOnPackage(slog.LevelWarn))
+		assert.False(t, levels.dependsOnPackage(slog.LevelError))
+	})
+
+	t.Run("set changes the levels", func(t *testing.T) {
+		levels.Set("error", nil)
+		assert.False(t, levels.dependsOnPackage(slog.LevelDebug))
+
+		assert.Equal(t, slog.LevelError, levels.Level())
+		assert.False(t, levels.enabled(slog.LevelDebug, "github.com/acme/service/db.Get"))
+	})
+}
+
+func TestLevelHandler(t *testing.T) {
+	newRecord := func(level slog.Level) slog.Record {
+		return slog.NewRecord(time.Now(), level, "message", 0)
+	}
+
+	t.Run("applies the global level", func(t *testing.T) {
+		next := &MockHandler{}
+		levels := NewLevels("info", nil)
+		handler := NewLevelHandler(levels)(next)
+
+		assert.False(t, handler.Enabled(context.Background(), slog.LevelDebug))
+		assert.True(t, handler.Enabled(context.Background(), slog.LevelInfo))
+
+		// the level can be changed after the handler is created
+		levels.Set("debug", nil)
+		assert.True(t, handler.Enabled(context.Background(), slog.LevelDebug))
+	})
+
+	t.Run("applies the level of the caller package", func(t *testing.T) {
+		next := &MockHandler{}
+		// the frames of this package are skipped, so the caller is the testing package
+		handler := NewLevelHandler(NewLevels("error", map[string]string{"testing": "debug"}))(next)
+
+		require.True(t, handler.Enabled(context.Background(), slog.LevelDebug))
+		require.NoError(t, handler.Handle(context.Background(), newRecord(slog.LevelDebug)))
+		assert.NotNil(t, next.r)
+	})
+
+	t.Run("drops the records below the level of the caller package", func(t *testing.T) {
+		next := &MockHandler{}
+		handler := NewLevelHandler(NewLevels("debug", map[string]string{"testing": "error"}))(next)
+
+		require.NoError(t, handler.Handle(context.Background(), newRecord(slog.LevelWarn)))
+		assert.Nil(t, next.r)
+	})
+}
@@ -0,0 +1,306 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/logger"
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sort"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+)
+
+// truncations counts the number of log records that were truncated because of the limits.
+var truncations atomic.Uint64
+
+// TruncationCount returns the number of log records that have been truncated
+// since the start of the application.
+func TruncationCount() uint64 {
+	return truncations.Load()
+}
+
+// Limits bounds the size of the log records.
+//
+// A value less than or equal to zero disables the corresponding limit.
+type Limits struct {
+	// MaxValueLength is the maximum length of a single value
+	MaxValueLength int
+	// MaxMetadataKeys is the maximum number of keys in a group
+	MaxMetadataKeys int
+	// MaxDepth is the maximum nesting depth of the groups
+	MaxDepth int
+	// MaxRecordSize is the maximum size in bytes of a single log record
+	MaxRecordSize int
+}
+
+// NewLimits returns the Limits from the given logger configuration.
+func NewLimits(cfg config.LoggerConfig) Limits {
+	return Limits{
+		MaxValueLength:  cfg.MaxValueLength(),
+		MaxMetadataKeys: cfg.MaxMetadataKeys(),
+		MaxDepth:        cfg.MaxDepth(),
+		MaxRecordSize:   cfg.MaxRecordSize(),
+	}
+}
+
+// hasAttrLimits returns true if any of the limits that apply to the attributes is enabled.
+func (l Limits) hasAttrLimits() bool {
+	return l.MaxValueLength > 0 || l.MaxMetadataKeys > 0 || l.MaxDepth > 0
+}
+
+// LimitAttr applies the value length, number of keys and depth limits to the given attribute.
+//
+// Values longer than the limit are cut and suffixed with a marker, groups with too many keys
+// keep only the first keys and get an extra attribute with the number of dropped keys, and
+// groups nested deeper than the limit are replaced by the marker.
+// Every attribute that gets truncated is counted once in TruncationCount.
+func (l Limits) LimitAttr(attr slog.Attr) slog.Attr {
+	if !l.hasAttrLimits() {
+		return attr
+	}
+
+	truncated := false
+	attr.Value = l.limitValue(attr.Value, 0, &truncated)
+	if truncated {
+		truncations.Add(1)
+	}
+
+	return attr
+}
+
+// limitValue applies the limits to a slog.Value found at the given depth.
+func (l Limits) limitValue(value slog.Value, depth int, truncated *bool) slog.Value {
+	value = value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindString:
+		s, cut := l.truncateString(value.String())
+		if cut {
+			*truncated = true
+			return slog.StringValue(s)
+		}
+		return value
+
+	case slog.KindGroup:
+		if depth > 0 && l.MaxDepth > 0 && depth >= l.MaxDepth {
+			*truncated = true
+			return slog.StringValue(config.LOG_TRUNCATED_MARKER)
+		}
+
+		group := value.Group()
+		dropped := 0
+		if l.MaxMetadataKeys > 0 && len(group) > l.MaxMetadataKeys {
+			dropped = len(group) - l.MaxMetadataKeys
+			group = group[:l.MaxMetadataKeys]
+			*truncated = true
+		}
+
+		attrs := make([]slog.Attr, 0, len(group)+1)
+		for _, a := range group {
+			attrs = append(attrs, slog.Attr{Key: a.Key, Value: l.limitValue(a.Value, depth+1, truncated)})
+		}
+		if dropped > 0 {
+			attrs = append(attrs, slog.Int(config.LOG_TRUNCATED_KEY, dropped))
+		}
+		return slog.GroupValue(attrs...)
+
+	case slog.KindAny:
+		// arbitrary values (structs, maps, slices) are limited through their JSON representation
+		if e, ok := value.Any().(error); ok {
+			s, cut := l.truncateString(e.Error())
+			if cut {
+				*truncated = true
+				return slog.StringValue(s)
+			}
+			return value
+		}
+
+		raw, err := json.Marshal(value.Any())
+		if err != nil {
+			return value
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		var data interface{}
+		if err := decoder.Decode(&data); err != nil {
+			return value
+		}
+
+		limited, cut := l.limitJSON(data, depth)
+		if cut {
+			*truncated = true
+			return slog.AnyValue(limited)
+		}
+		return value
+
+	default:
+		return value
+	}
+}
+
+// limitJSON applies the limits to a decoded JSON value found at the given depth.
+//
+// It returns the limited value and whether anything was truncated.
+func (l Limits) limitJSON(data interface{}, depth int) (interface{}, bool) {
+	switch v := data.(type) {
+	case string:
+		return l.truncateString(v)
+
+	case map[string]interface{}:
+		if depth > 0 && l.MaxDepth > 0 && depth >= l.MaxDepth {
+			return config.LOG_TRUNCATED_MARKER, true
+		}
+
+		// sort the keys, so the same keys are kept every time
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		truncated := false
+		result := make(map[string]interface{}, len(v))
+		if l.MaxMetadataKeys > 0 && len(keys) > l.MaxMetadataKeys {
+			result[config.LOG_TRUNCATED_KEY] = len(keys) - l.MaxMetadataKeys
+			keys = keys[:l.MaxMetadataKeys]
+			truncated = true
+		}
+		for _, k := range keys {
+			value, cut := l.limitJSON(v[k], depth+1)
+			truncated = truncated || cut
+			result[k] = value
+		}
+		return result, truncated
+
+	case []interface{}:
+		if depth > 0 && l.MaxDepth > 0 && depth >= l.MaxDepth {
+			return config.LOG_TRUNCATED_MARKER, true
+		}
+
+		truncated := false
+		result := make([]interface{}, 0, len(v))
+		for i, item := range v {
+			if l.MaxMetadataKeys > 0 && i >= l.MaxMetadataKeys {
+				result = append(result, config.LOG_TRUNCATED_MARKER)
+				truncated = true
+				break
+			}
+			value, cut := l.limitJSON(item, depth+1)
+			truncated = truncated || cut
+			result = append(result, value)
+		}
+		return result, truncated
+
+	default:
+		return v, false
+	}
+}
+
+// truncateString cuts the given string to the maximum value length, without splitting
+// a multi-byte character, and appends the truncation marker.
+//
+// It returns the (possibly) truncated string and whether it was truncated.
+func (l Limits) truncateString(s string) (string, bool) {
+	if l.MaxValueLength <= 0 || len(s) <= l.MaxValueLength {
+		return s, false
+	}
+
+	cut := l.MaxValueLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return s[:cut] + config.LOG_TRUNCATED_MARKER, true
+}
+
+// recordSizeWriter is an io.Writer that enforces the maximum size of a JSON log record.
+//
+// The slog.JSONHandler writes each record with a single call to Write,
+// so every call is treated as one complete record.
+type recordSizeWriter struct {
+	w      io.Writer
+	limits Limits
+}
+
+// Write writes the record to the underlying writer, shrinking it first if it is
+// larger than the maximum record size.
+func (w *recordSizeWriter) Write(p []byte) (int, error) {
+	if w.limits.MaxRecordSize <= 0 || len(p) <= w.limits.MaxRecordSize {
+		return w.w.Write(p)
+	}
+
+	truncations.Add(1)
+	if _, err := w.w.Write(shrinkRecord(p, w.limits.MaxRecordSize)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// shrinkRecord reduces the size of an encoded JSON log record.
+//
+// First the metadata is replaced by the truncation marker. If the record is still
+// too large, every remaining string value is cut to a share of the maximum size.
+// The record is always kept as valid JSON, even if it still exceeds the limit.
+func shrinkRecord(p []byte, maxSize int) []byte {
+	var record map[string]json.RawMessage
+	if err := json.Unmarshal(p, &record); err != nil {
+		return p
+	}
+
+	marker, _ := json.Marshal(config.LOG_TRUNCATED_MARKER)
+	if _, ok := record[config.LOG_METADATA_KEY]; ok {
+		record[config.LOG_METADATA_KEY] = marker
+	}
+	record[config.LOG_TRUNCATED_KEY] = json.RawMessage("true")
+
+	result, err := json.Marshal(record)
+	if err != nil {
+		return p
+	}
+
+	if len(result)+1 > maxSize {
+		budget := Limits{MaxValueLength: max(maxSize/(len(record)+1), 1)}
+		for key, raw := range record {
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				continue
+			}
+
+			if cut, ok := budget.truncateString(s); ok {
+				record[key], _ = json.Marshal(cut)
+			}
+		}
+
+		if shrunk, err := json.Marshal(record); err == nil {
+			result = shrunk
+		}
+	}
+
+	return append(result, '\n')
+}
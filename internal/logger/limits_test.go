@@ -0,0 +1,147 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitAttr(t *testing.T) {
+	t.Run("Without limits the attribute is unchanged", func(t *testing.T) {
+		attr := slog.Group(config.LOG_METADATA_KEY, slog.String("key", strings.Repeat("a", 100)))
+		result := Limits{}.LimitAttr(attr)
+
+		assert.True(t, attr.Equal(result))
+	})
+
+	t.Run("Long values are truncated", func(t *testing.T) {
+		before := TruncationCount()
+		attr := slog.Group(config.LOG_METADATA_KEY, slog.String("key", strings.Repeat("a", 100)))
+		result := Limits{MaxValueLength: 10}.LimitAttr(attr)
+
+		value := result.Value.Group()[0].Value.String()
+		assert.Equal(t, strings.Repeat("a", 10)+config.LOG_TRUNCATED_MARKER, value)
+		assert.Equal(t, before+1, TruncationCount())
+	})
+
+	t.Run("Multi-byte characters are not split", func(t *testing.T) {
+		attr := slog.Group(config.LOG_METADATA_KEY, slog.String("key", "ααααα"))
+		result := Limits{MaxValueLength: 3}.LimitAttr(attr)
+
+		value := result.Value.Group()[0].Value.String()
+		assert.Equal(t, "α"+config.LOG_TRUNCATED_MARKER, value)
+	})
+
+	t.Run("Extra keys are dropped", func(t *testing.T) {
+		attr := slog.Group(config.LOG_METADATA_KEY,
+			slog.String("key1", "value1"),
+			slog.String("key2", "value2"),
+			slog.String("key3", "value3"),
+		)
+		result := Limits{MaxMetadataKeys: 2}.LimitAttr(attr)
+
+		group := result.Value.Group()
+		require.Len(t, group, 3)
+		assert.Equal(t, "key1", group[0].Key)
+		assert.Equal(t, "key2", group[1].Key)
+		assert.Equal(t, config.LOG_TRUNCATED_KEY, group[2].Key)
+		assert.Equal(t, int64(1), group[2].Value.Int64())
+	})
+
+	t.Run("Deeply nested groups are replaced", func(t *testing.T) {
+		attr := slog.Group(config.LOG_METADATA_KEY,
+			slog.String("key1", "value1"),
+			slog.Group("nested", slog.Group("deeper", slog.String("key2", "value2"))),
+		)
+		result := Limits{MaxDepth: 2}.LimitAttr(attr)
+
+		nested := result.Value.Group()[1].Value.Group()
+		require.Len(t, nested, 1)
+		assert.Equal(t, config.LOG_TRUNCATED_MARKER, nested[0].Value.String())
+	})
+
+	t.Run("Structs are limited through their JSON representation", func(t *testing.T) {
+		type payload struct {
+			Name  string
+			Items map[string]string
+		}
+		attr := slog.Group(config.LOG_METADATA_KEY,
+			slog.Any("payload", payload{Name: strings.Repeat("b", 50), Items: map[string]string{"a": "1"}}),
+		)
+		result := Limits{MaxValueLength: 5, MaxDepth: 2}.LimitAttr(attr)
+
+		value, ok := result.Value.Group()[0].Value.Any().(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "bbbbb"+config.LOG_TRUNCATED_MARKER, value["Name"])
+		assert.Equal(t, config.LOG_TRUNCATED_MARKER, value["Items"])
+	})
+}
+
+func TestRecordSizeWriter(t *testing.T) {
+	t.Run("Small records are written unchanged", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		l := slog.New(slog.NewJSONHandler(&recordSizeWriter{w: output, limits: Limits{MaxRecordSize: 1024}}, nil))
+		l.Info("message", slog.Group(config.LOG_METADATA_KEY, slog.String("key", "value")))
+
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+		assert.Equal(t, map[string]interface{}{"key": "value"}, record[config.LOG_METADATA_KEY])
+		assert.NotContains(t, record, config.LOG_TRUNCATED_KEY)
+	})
+
+	t.Run("Large records drop the metadata", func(t *testing.T) {
+		before := TruncationCount()
+		output := &bytes.Buffer{}
+		l := slog.New(slog.NewJSONHandler(&recordSizeWriter{w: output, limits: Limits{MaxRecordSize: 200}}, nil))
+		l.Info("message", slog.Group(config.LOG_METADATA_KEY, slog.String("key", strings.Repeat("a", 1000))))
+
+		assert.LessOrEqual(t, output.Len(), 200)
+		assert.True(t, bytes.HasSuffix(output.Bytes(), []byte("\n")))
+
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+		assert.Equal(t, config.LOG_TRUNCATED_MARKER, record[config.LOG_METADATA_KEY])
+		assert.Equal(t, true, record[config.LOG_TRUNCATED_KEY])
+		assert.Equal(t, "message", record["msg"])
+		assert.Equal(t, before+1, TruncationCount())
+	})
+
+	t.Run("Large messages are cut", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		l := slog.New(slog.NewJSONHandler(&recordSizeWriter{w: output, limits: Limits{MaxRecordSize: 300}}, nil))
+		l.Info(strings.Repeat("m", 1000))
+
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+		assert.True(t, strings.HasSuffix(record["msg"].(string), config.LOG_TRUNCATED_MARKER))
+		assert.Less(t, output.Len(), 1000)
+	})
+}
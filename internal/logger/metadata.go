@@ -0,0 +1,175 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/logger"
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+)
+
+// CollisionPolicy decides what happens to a flat metadata key that clashes with a reserved key.
+type CollisionPolicy string
+
+const (
+	// CollisionPrefix prefixes the colliding key with "metadata."
+	CollisionPrefix CollisionPolicy = "prefix"
+	// CollisionRename appends a numeric suffix to the colliding key, e.g. "service.name_1".
+	// Keys under a reserved prefix, e.g. "code.", are prefixed with "metadata." instead
+	CollisionRename CollisionPolicy = "rename"
+	// CollisionDrop drops the colliding key
+	CollisionDrop CollisionPolicy = "drop"
+)
+
+// collisions counts the number of metadata keys that clashed with a reserved key.
+var collisions atomic.Uint64
+
+// CollisionCount returns the number of flat metadata keys that have clashed with
+// a reserved key since the start of the application.
+func CollisionCount() uint64 {
+	return collisions.Load()
+}
+
+// reservedKeys are the top level keys of the log record that are owned by the logger.
+var reservedKeys = map[string]struct{}{
+	slog.TimeKey:                               {},
+	slog.LevelKey:                              {},
+	slog.MessageKey:                            {},
+	config.LOG_MESSAGE_KEY:                     {},
+	config.LOG_ERROR_KEY:                       {},
+	config.LOG_METADATA_KEY:                    {},
+	config.SERVICE_NAME:                        {},
+	config.SERVICE_VERSION:                     {},
+	config.SERVICE_INTANCE_ID:                  {},
+	config.FILE_PATH:                           {},
+	config.LINE_NUMBER:                         {},
+	config.FUNCTION_NAME:                       {},
+	config.FUNCTION_PACKAGE_NAME:               {},
+	config.EVENT_NAME:                          {},
+	config.ERROR_REPORTING_TYPE_KEY:            {},
+	config.ERROR_REPORTING_STACK_TRACE_KEY:     {},
+	config.ERROR_REPORTING_SERVICE_CONTEXT_KEY: {},
+	config.ERROR_REPORTING_CONTEXT_KEY:         {},
+	traceIDKey:                                 {},
+	spanIDKey:                                  {},
+}
+
+// reservedPrefixes are the key prefixes that are owned by the logger.
+// Keys under these namespaces are treated as colliding, even if the logger
+// does not currently emit them.
+var reservedPrefixes = []string{"code."}
+
+// IsReservedKey returns true if the given key is owned by the logger
+// and must not be overwritten by the metadata.
+func IsReservedKey(key string) bool {
+	if _, ok := reservedKeys[key]; ok {
+		return true
+	}
+
+	return hasReservedPrefix(key)
+}
+
+// hasReservedPrefix returns true if the given key is under one of the reserved prefixes.
+func hasReservedPrefix(key string) bool {
+	for _, prefix := range reservedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseCollisionPolicy converts a string to a CollisionPolicy.
+//
+// If the string is not a valid policy, it defaults to prefix.
+func ParseCollisionPolicy(policy string) CollisionPolicy {
+	switch CollisionPolicy(strings.ToLower(policy)) {
+	case CollisionRename:
+		return CollisionRename
+	case CollisionDrop:
+		return CollisionDrop
+	default:
+		return CollisionPrefix
+	}
+}
+
+// ResolveCollisions applies the collision policy to the metadata attributes that are
+// going to be emitted at the top level of the log record.
+//
+// Attributes whose key is not reserved are returned unchanged and in the same order.
+// Every colliding attribute is counted in CollisionCount.
+func ResolveCollisions(attrs []slog.Attr, policy CollisionPolicy) []slog.Attr {
+	result := make([]slog.Attr, 0, len(attrs))
+	used := make(map[string]struct{}, len(attrs))
+	for _, attr := range attrs {
+		used[attr.Key] = struct{}{}
+	}
+
+	for _, attr := range attrs {
+		if !IsReservedKey(attr.Key) {
+			result = append(result, attr)
+			continue
+		}
+
+		collisions.Add(1)
+		switch policy {
+		case CollisionDrop:
+			continue
+		case CollisionRename:
+			attr.Key = renameKey(attr.Key, used)
+		default:
+			attr.Key = config.LOG_METADATA_KEY + "." + attr.Key
+		}
+
+		used[attr.Key] = struct{}{}
+		result = append(result, attr)
+	}
+
+	return result
+}
+
+// renameKey appends the first numeric suffix to the key that results in a key
+// which is not reserved by the logger and is not already used.
+//
+// A suffix can't move a key out of a reserved prefix (e.g. "code.function_1" is still under "code."),
+// so these keys are prefixed with "metadata." instead.
+func renameKey(key string, used map[string]struct{}) string {
+	if hasReservedPrefix(key) {
+		return config.LOG_METADATA_KEY + "." + key
+	}
+
+	for i := 1; ; i++ {
+		candidate := key + "_" + strconv.Itoa(i)
+		if _, ok := used[candidate]; ok {
+			continue
+		}
+		if IsReservedKey(candidate) {
+			continue
+		}
+		return candidate
+	}
+}
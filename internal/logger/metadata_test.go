@@ -0,0 +1,113 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReservedKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{config.SERVICE_NAME, true},
+		{config.LOG_MESSAGE_KEY, true},
+		{traceIDKey, true},
+		{spanIDKey, true},
+		{config.FUNCTION_NAME, true},
+		{"code.custom", true},
+		{"booking_id", false},
+		{"service", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsReservedKey(tt.key))
+		})
+	}
+}
+
+func TestParseCollisionPolicy(t *testing.T) {
+	assert.Equal(t, CollisionPrefix, ParseCollisionPolicy("prefix"))
+	assert.Equal(t, CollisionRename, ParseCollisionPolicy("RENAME"))
+	assert.Equal(t, CollisionDrop, ParseCollisionPolicy("drop"))
+	assert.Equal(t, CollisionPrefix, ParseCollisionPolicy("invalid"))
+	assert.Equal(t, CollisionPrefix, ParseCollisionPolicy(""))
+}
+
+func TestResolveCollisions(t *testing.T) {
+	attrs := []slog.Attr{
+		slog.String("booking_id", "123"),
+		slog.String(config.SERVICE_NAME, "other-service"),
+		slog.String(traceIDKey, "abc"),
+		slog.String("trace_id_1", "taken"),
+	}
+
+	t.Run("Prefix policy", func(t *testing.T) {
+		before := CollisionCount()
+		result := ResolveCollisions(attrs, CollisionPrefix)
+
+		assert.Equal(t, []string{"booking_id", "metadata.service.name", "metadata.trace_id", "trace_id_1"}, keys(result))
+		assert.Equal(t, before+2, CollisionCount())
+	})
+
+	t.Run("Rename policy", func(t *testing.T) {
+		result := ResolveCollisions(attrs, CollisionRename)
+
+		assert.Equal(t, []string{"booking_id", "service.name_1", "trace_id_2", "trace_id_1"}, keys(result))
+	})
+
+	t.Run("Rename policy with a reserved prefix", func(t *testing.T) {
+		result := ResolveCollisions([]slog.Attr{
+			slog.String(config.FUNCTION_NAME, "handler"),
+			slog.String("code.custom", "value"),
+		}, CollisionRename)
+
+		assert.Equal(t, []string{"metadata." + config.FUNCTION_NAME, "metadata.code.custom"}, keys(result))
+	})
+
+	t.Run("Drop policy", func(t *testing.T) {
+		result := ResolveCollisions(attrs, CollisionDrop)
+
+		assert.Equal(t, []string{"booking_id", "trace_id_1"}, keys(result))
+	})
+
+	t.Run("Values are kept", func(t *testing.T) {
+		result := ResolveCollisions(attrs, CollisionPrefix)
+
+		assert.Equal(t, "other-service", result[1].Value.String())
+	})
+}
+
+func keys(attrs []slog.Attr) []string {
+	result := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		result = append(result, attr.Key)
+	}
+	return result
+}
@@ -42,12 +42,12 @@ type TracingHandler struct {
 	// next is the next handler in the chain
 	next slog.Handler
 	// level is the minimum level of log that will be handled
-	level slog.Level
+	level slog.Leveler
 }
 
 // Enabled returns true if the log level is greater than or equal to the handler's level
 func (h *TracingHandler) Enabled(ctx context.Context, level slog.Level) bool {
-	return level >= h.level
+	return level >= h.level.Level()
 }
 
 // Handle adds the trace and span ids to the log record and passes it to the next handler
@@ -101,7 +101,7 @@ func (h *TracingHandler) Handler() slog.Handler {
 // the tracing output level.
 //
 // Returns an slogmulti.Middleware
-func NewTracingHandler(level slog.Level) slogmulti.Middleware {
+func NewTracingHandler(level slog.Leveler) slogmulti.Middleware {
 	return func(next slog.Handler) slog.Handler {
 		return &TracingHandler{
 			next:  next,
@@ -0,0 +1,114 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package option // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/option"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+)
+
+// options are the options of the logger, the tracer, the meter, the HTTP client and the middlewares
+type options struct {
+	config         *config.Monitoring
+	tracerProvider oteltrace.TracerProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the logger, the tracer, the meter, the HTTP client or the middlewares
+type Option func(*options)
+
+// WithConfig uses the given configuration (see the monitoring/config package),
+// instead of the process-wide configuration read from the environment.
+func WithConfig(cfg config.Monitoring) Option {
+	return func(o *options) {
+		o.config = &cfg
+	}
+}
+
+// WithTracerProvider uses the given tracer provider, instead of the global one.
+// A nil provider is ignored.
+func WithTracerProvider(provider oteltrace.TracerProvider) Option {
+	return func(o *options) {
+		if provider != nil {
+			o.tracerProvider = provider
+		}
+	}
+}
+
+// WithPropagators uses the given propagators, instead of the global ones.
+// Nil propagators are ignored.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		if propagators != nil {
+			o.propagators = propagators
+		}
+	}
+}
+
+// MonitoringConfig returns the configuration of the options.
+// If no configuration is given, the process-wide configuration is returned.
+func MonitoringConfig(opts []Option) config.Monitoring {
+	if cfg, ok := ExplicitConfig(opts); ok {
+		return cfg
+	}
+	return config.NewMonitoringConfig()
+}
+
+// ExplicitConfig returns the configuration given with WithConfig, and whether it was given.
+func ExplicitConfig(opts []Option) (config.Monitoring, bool) {
+	o := apply(opts)
+	if o.config == nil {
+		return config.Monitoring{}, false
+	}
+	return *o.config, true
+}
+
+// TracerProvider returns the tracer provider of the options.
+// If no tracer provider is given, the global provider is returned.
+func TracerProvider(opts []Option) oteltrace.TracerProvider {
+	if o := apply(opts); o.tracerProvider != nil {
+		return o.tracerProvider
+	}
+	return otel.GetTracerProvider()
+}
+
+// Propagators returns the propagators of the options.
+// If no propagators are given, the global propagators are returned.
+func Propagators(opts []Option) propagation.TextMapPropagator {
+	if o := apply(opts); o.propagators != nil {
+		return o.propagators
+	}
+	return otel.GetTextMapPropagator()
+}
+
+// apply applies the given options
+func apply(opts []Option) options {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,201 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package resource // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	// metadataHostEnv is the environment variable that overrides the host of the GCP metadata server,
+	// as in the Google Cloud client libraries (e.g. to use a fake metadata server).
+	metadataHostEnv = "GCE_METADATA_HOST"
+	// defaultMetadataHost is the host of the GCP metadata server.
+	defaultMetadataHost = "169.254.169.254"
+	// metadataTimeout is the deadline of each request to the metadata server.
+	metadataTimeout = 2 * time.Second
+	// productNameFile is the file with the product name of the machine, which is Google on GCP.
+	productNameFile = "/sys/class/dmi/id/product_name"
+)
+
+// metadataClient reads values from the GCP metadata server.
+type metadataClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// newMetadataClient returns a metadataClient for the metadata server at the given host.
+func newMetadataClient(host string) metadataClient {
+	return metadataClient{
+		baseURL: "http://" + host + "/computeMetadata/v1/",
+		client:  &http.Client{Timeout: metadataTimeout},
+	}
+}
+
+// get returns the value at the given path (e.g. "project/project-id").
+func (c metadataClient) get(ctx context.Context, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata server returned %s for %s", resp.Status, path)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// gcpDetector detects the GCP platform of the process: Cloud Run, GKE or Compute Engine.
+type gcpDetector struct {
+	metadata        metadataClient
+	productNameFile string
+}
+
+// newGCPDetector returns a gcpDetector which reads the metadata server of `GCE_METADATA_HOST`,
+// or the default one.
+func newGCPDetector() gcpDetector {
+	host := env(metadataHostEnv)
+	if host == "" {
+		host = defaultMetadataHost
+	}
+
+	return gcpDetector{
+		metadata:        newMetadataClient(host),
+		productNameFile: productNameFile,
+	}
+}
+
+// onGCP returns whether the process runs on GCP. It never calls the metadata server,
+// so the detection is free outside of GCP.
+func (d gcpDetector) onGCP() bool {
+	return env(metadataHostEnv) != "" ||
+		env("K_SERVICE") != "" ||
+		strings.Contains(readFile(d.productNameFile), "Google")
+}
+
+// Detect returns the cloud.* attributes and the attributes of the platform, or an empty resource outside of GCP.
+//
+// The platform is Cloud Run if `K_SERVICE` is set, GKE if `KUBERNETES_SERVICE_HOST` is set, and Compute Engine otherwise.
+// The values which cannot be read from the metadata server are skipped, so the detection never fails.
+func (d gcpDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if !d.onGCP() {
+		return resource.Empty(), nil
+	}
+
+	attrs := []attribute.KeyValue{semconv.CloudProviderGCP}
+
+	projectID, err := d.metadata.get(ctx, "project/project-id")
+	if err != nil {
+		// the metadata server is not reachable, so only the attributes of the environment are kept
+		return resource.NewSchemaless(append(attrs, d.environmentAttributes()...)...), nil
+	}
+	attrs = append(attrs, semconv.CloudAccountID(projectID))
+	attrs = append(attrs, d.environmentAttributes()...)
+
+	switch {
+	case env("K_SERVICE") != "":
+		attrs = d.appendMetadata(ctx, attrs, semconv.FaaSInstanceKey, "instance/id", nil)
+		attrs = d.appendMetadata(ctx, attrs, semconv.CloudRegionKey, "instance/region", lastSegment)
+	case env("KUBERNETES_SERVICE_HOST") != "":
+		attrs = d.appendMetadata(ctx, attrs, semconv.K8SClusterNameKey, "instance/attributes/cluster-name", nil)
+		attrs = d.appendMetadata(ctx, attrs, semconv.HostIDKey, "instance/id", nil)
+		if location, err := d.metadata.get(ctx, "instance/attributes/cluster-location"); err == nil {
+			attrs = append(attrs, locationAttributes(location)...)
+		}
+	default:
+		attrs = d.appendMetadata(ctx, attrs, semconv.HostIDKey, "instance/id", nil)
+		attrs = d.appendMetadata(ctx, attrs, semconv.HostNameKey, "instance/name", nil)
+		attrs = d.appendMetadata(ctx, attrs, semconv.HostTypeKey, "instance/machine-type", lastSegment)
+		if zone, err := d.metadata.get(ctx, "instance/zone"); err == nil {
+			attrs = append(attrs, locationAttributes(lastSegment(zone))...)
+		}
+	}
+
+	return resource.NewSchemaless(attrs...), nil
+}
+
+// environmentAttributes returns the attributes of the platform which are read from the environment.
+func (d gcpDetector) environmentAttributes() []attribute.KeyValue {
+	switch {
+	case env("K_SERVICE") != "":
+		attrs := []attribute.KeyValue{semconv.CloudPlatformGCPCloudRun, semconv.FaaSName(env("K_SERVICE"))}
+		if revision := env("K_REVISION"); revision != "" {
+			attrs = append(attrs, semconv.FaaSVersion(revision))
+		}
+		return attrs
+	case env("KUBERNETES_SERVICE_HOST") != "":
+		return []attribute.KeyValue{semconv.CloudPlatformGCPKubernetesEngine}
+	default:
+		return []attribute.KeyValue{semconv.CloudPlatformGCPComputeEngine}
+	}
+}
+
+// appendMetadata appends the value at the given path of the metadata server, if it can be read,
+// optionally converted by the given function.
+func (d gcpDetector) appendMetadata(ctx context.Context, attrs []attribute.KeyValue, key attribute.Key, path string, convert func(string) string) []attribute.KeyValue {
+	value, err := d.metadata.get(ctx, path)
+	if err != nil || value == "" {
+		return attrs
+	}
+	if convert != nil {
+		value = convert(value)
+	}
+	return append(attrs, key.String(value))
+}
+
+// lastSegment returns the last segment of a path (e.g. "projects/123/zones/europe-west1-b").
+func lastSegment(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// locationAttributes returns the region of the given location, and its availability zone
+// if the location is a zone (e.g. "europe-west1-b" is in the region "europe-west1").
+func locationAttributes(location string) []attribute.KeyValue {
+	if strings.Count(location, "-") < 2 {
+		return []attribute.KeyValue{semconv.CloudRegion(location)}
+	}
+
+	region := location[:strings.LastIndex(location, "-")]
+	return []attribute.KeyValue{semconv.CloudRegion(region), semconv.CloudAvailabilityZone(location)}
+}
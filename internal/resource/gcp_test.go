@@ -0,0 +1,154 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package resource // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMetadataServer starts a fake GCP metadata server with the given values,
+// and points `GCE_METADATA_HOST` to it.
+func fakeMetadataServer(t *testing.T, values map[string]string) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		value, ok := values[strings.TrimPrefix(r.URL.Path, "/computeMetadata/v1/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(value))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv(metadataHostEnv, strings.TrimPrefix(server.URL, "http://"))
+}
+
+func TestGCPDetector(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("detects nothing outside of GCP", func(t *testing.T) {
+		t.Setenv(metadataHostEnv, "")
+		t.Setenv("K_SERVICE", "")
+
+		detector := gcpDetector{productNameFile: "/does/not/exist"}
+		res, err := detector.Detect(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, res.Attributes())
+	})
+
+	t.Run("detects Cloud Run", func(t *testing.T) {
+		t.Setenv("K_SERVICE", "api")
+		t.Setenv("K_REVISION", "api-00001")
+		t.Setenv("KUBERNETES_SERVICE_HOST", "")
+		fakeMetadataServer(t, map[string]string{
+			"project/project-id": "acme",
+			"instance/id":        "instance-1",
+			"instance/region":    "projects/123/regions/europe-west1",
+		})
+
+		res, err := newGCPDetector().Detect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"cloud.provider":   "gcp",
+			"cloud.platform":   "gcp_cloud_run",
+			"cloud.account.id": "acme",
+			"cloud.region":     "europe-west1",
+			"faas.name":        "api",
+			"faas.version":     "api-00001",
+			"faas.instance":    "instance-1",
+		}, attributes(res))
+	})
+
+	t.Run("detects GKE", func(t *testing.T) {
+		t.Setenv("K_SERVICE", "")
+		t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+		fakeMetadataServer(t, map[string]string{
+			"project/project-id":               "acme",
+			"instance/id":                      "1234",
+			"instance/attributes/cluster-name": "prod",
+			// the cluster-location is missing, so it is skipped
+		})
+
+		res, err := newGCPDetector().Detect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"cloud.provider":   "gcp",
+			"cloud.platform":   "gcp_kubernetes_engine",
+			"cloud.account.id": "acme",
+			"k8s.cluster.name": "prod",
+			"host.id":          "1234",
+		}, attributes(res))
+	})
+
+	t.Run("detects Compute Engine", func(t *testing.T) {
+		t.Setenv("K_SERVICE", "")
+		t.Setenv("KUBERNETES_SERVICE_HOST", "")
+		fakeMetadataServer(t, map[string]string{
+			"project/project-id":    "acme",
+			"instance/id":           "1234",
+			"instance/name":         "vm-1",
+			"instance/machine-type": "projects/123/machineTypes/e2-medium",
+			"instance/zone":         "projects/123/zones/europe-west1-b",
+		})
+
+		res, err := newGCPDetector().Detect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"cloud.provider":          "gcp",
+			"cloud.platform":          "gcp_compute_engine",
+			"cloud.account.id":        "acme",
+			"cloud.region":            "europe-west1",
+			"cloud.availability_zone": "europe-west1-b",
+			"host.id":                 "1234",
+			"host.name":               "vm-1",
+			"host.type":               "e2-medium",
+		}, attributes(res))
+	})
+
+	t.Run("keeps the environment if the metadata server is not reachable", func(t *testing.T) {
+		t.Setenv("K_SERVICE", "api")
+		t.Setenv("K_REVISION", "")
+		fakeMetadataServer(t, map[string]string{})
+
+		res, err := newGCPDetector().Detect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"cloud.provider": "gcp",
+			"cloud.platform": "gcp_cloud_run",
+			"faas.name":      "api",
+		}, attributes(res))
+	})
+}
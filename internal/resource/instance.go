@@ -0,0 +1,63 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package resource // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// processInstanceID is the random instance id of the process, used when the platform does not identify the instance.
+var processInstanceID = sync.OnceValue(func() string {
+	return uuid.NewString()
+})
+
+// instanceID returns the `service.instance.id` of the detected resource, which is stable for the lifetime of the
+// instance and identical across all signals:
+//
+//   - on Kubernetes, `<namespace>.<pod>.<container>` (the container is omitted if unknown)
+//   - on Cloud Run, the id of the instance
+//   - otherwise, a random id generated once per process
+func instanceID(detected *resource.Resource) string {
+	set := detected.Set()
+
+	namespace, _ := set.Value(semconv.K8SNamespaceNameKey)
+	pod, _ := set.Value(semconv.K8SPodNameKey)
+	if namespace.AsString() != "" && pod.AsString() != "" {
+		parts := []string{namespace.AsString(), pod.AsString()}
+		if container, ok := set.Value(semconv.K8SContainerNameKey); ok && container.AsString() != "" {
+			parts = append(parts, container.AsString())
+		}
+		return strings.Join(parts, ".")
+	}
+
+	if instance, ok := set.Value(semconv.FaaSInstanceKey); ok && instance.AsString() != "" {
+		return instance.AsString()
+	}
+
+	return processInstanceID()
+}
@@ -0,0 +1,125 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package resource // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	// defaultPodInfoDir is the default mount path of the downward-API volume.
+	defaultPodInfoDir = "/etc/podinfo"
+	// defaultServiceAccountDir is the directory of the service account, mounted in every pod.
+	defaultServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// kubernetesDetector detects the pod of the process, from the Kubernetes downward-API
+// environment variables and files.
+type kubernetesDetector struct {
+	podInfoDir        string
+	serviceAccountDir string
+}
+
+// newKubernetesDetector returns a kubernetesDetector which reads the default directories.
+func newKubernetesDetector() kubernetesDetector {
+	return kubernetesDetector{
+		podInfoDir:        defaultPodInfoDir,
+		serviceAccountDir: defaultServiceAccountDir,
+	}
+}
+
+// Detect returns the k8s.* attributes of the pod, or an empty resource outside of Kubernetes.
+//
+// Every attribute is read from the first of the sources which is set:
+//
+//   - k8s.pod.name: `K8S_POD_NAME`, `OTEL_RESOURCE_ATTRIBUTES_POD_NAME` (set by the OpenTelemetry Operator),
+//     the `name` file of the downward-API volume, `HOSTNAME`
+//   - k8s.pod.uid: `K8S_POD_UID`, `OTEL_RESOURCE_ATTRIBUTES_POD_UID`, the `uid` file of the downward-API volume
+//   - k8s.namespace.name: `K8S_NAMESPACE_NAME`, the `namespace` file of the downward-API volume,
+//     the namespace of the service account
+//   - k8s.node.name: `K8S_NODE_NAME`, `OTEL_RESOURCE_ATTRIBUTES_NODE_NAME`
+//   - k8s.container.name: `K8S_CONTAINER_NAME`
+//
+// The downward-API volume is expected at /etc/podinfo.
+func (d kubernetesDetector) Detect(context.Context) (*resource.Resource, error) {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return resource.Empty(), nil
+	}
+
+	values := []struct {
+		key     attribute.Key
+		sources []string
+	}{
+		{semconv.K8SPodNameKey, []string{env("K8S_POD_NAME"), env("OTEL_RESOURCE_ATTRIBUTES_POD_NAME"), d.podInfo("name"), env("HOSTNAME")}},
+		{semconv.K8SPodUIDKey, []string{env("K8S_POD_UID"), env("OTEL_RESOURCE_ATTRIBUTES_POD_UID"), d.podInfo("uid")}},
+		{semconv.K8SNamespaceNameKey, []string{env("K8S_NAMESPACE_NAME"), d.podInfo("namespace"), readFile(filepath.Join(d.serviceAccountDir, "namespace"))}},
+		{semconv.K8SNodeNameKey, []string{env("K8S_NODE_NAME"), env("OTEL_RESOURCE_ATTRIBUTES_NODE_NAME")}},
+		{semconv.K8SContainerNameKey, []string{env("K8S_CONTAINER_NAME")}},
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(values))
+	for _, v := range values {
+		if value := firstNonEmpty(v.sources); value != "" {
+			attrs = append(attrs, v.key.String(value))
+		}
+	}
+
+	return resource.NewSchemaless(attrs...), nil
+}
+
+// podInfo returns the content of a file of the downward-API volume, or an empty string.
+func (d kubernetesDetector) podInfo(name string) string {
+	return readFile(filepath.Join(d.podInfoDir, name))
+}
+
+// env returns the value of the environment variable without the surrounding white space.
+func env(name string) string {
+	return strings.TrimSpace(os.Getenv(name))
+}
+
+// readFile returns the content of the file without the surrounding white space,
+// or an empty string if it cannot be read.
+func readFile(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// firstNonEmpty returns the first value which is not empty.
+func firstNonEmpty(values []string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package resource // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// attributes returns the attributes of the resource as strings.
+func attributes(res *resource.Resource) map[string]string {
+	attrs := map[string]string{}
+	for _, attr := range res.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	return attrs
+}
+
+// writePodInfo writes the files of a downward-API volume to a temporary directory.
+func writePodInfo(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content+"\n"), 0o600))
+	}
+	return dir
+}
+
+func TestKubernetesDetector(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("detects nothing outside of Kubernetes", func(t *testing.T) {
+		t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+		res, err := newKubernetesDetector().Detect(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, res.Attributes())
+	})
+
+	t.Run("reads the environment variables", func(t *testing.T) {
+		t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+		t.Setenv("K8S_POD_NAME", "api-7d9f")
+		t.Setenv("K8S_POD_UID", "uid-1")
+		t.Setenv("K8S_NAMESPACE_NAME", "payments")
+		t.Setenv("OTEL_RESOURCE_ATTRIBUTES_NODE_NAME", "node-1")
+		t.Setenv("K8S_CONTAINER_NAME", "main")
+
+		detector := kubernetesDetector{podInfoDir: t.TempDir(), serviceAccountDir: t.TempDir()}
+		res, err := detector.Detect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"k8s.pod.name":       "api-7d9f",
+			"k8s.pod.uid":        "uid-1",
+			"k8s.namespace.name": "payments",
+			"k8s.node.name":      "node-1",
+			"k8s.container.name": "main",
+		}, attributes(res))
+	})
+
+	t.Run("reads the downward-API files", func(t *testing.T) {
+		t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+		t.Setenv("HOSTNAME", "hostname")
+
+		detector := kubernetesDetector{
+			podInfoDir:        writePodInfo(t, map[string]string{"name": "api-7d9f", "uid": "uid-1"}),
+			serviceAccountDir: writePodInfo(t, map[string]string{"namespace": "payments"}),
+		}
+		res, err := detector.Detect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"k8s.pod.name":       "api-7d9f",
+			"k8s.pod.uid":        "uid-1",
+			"k8s.namespace.name": "payments",
+		}, attributes(res))
+	})
+
+	t.Run("falls back to the hostname", func(t *testing.T) {
+		t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+		t.Setenv("HOSTNAME", "api-7d9f")
+
+		detector := kubernetesDetector{podInfoDir: t.TempDir(), serviceAccountDir: t.TempDir()}
+		res, err := detector.Detect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"k8s.pod.name": "api-7d9f"}, attributes(res))
+	})
+}
+
+func TestInstanceID(t *testing.T) {
+	t.Run("uses the pod", func(t *testing.T) {
+		res := resource.NewSchemaless(
+			attribute.String("k8s.namespace.name", "payments"),
+			attribute.String("k8s.pod.name", "api-7d9f"),
+			attribute.String("k8s.container.name", "main"),
+		)
+		assert.Equal(t, "payments.api-7d9f.main", instanceID(res))
+	})
+
+	t.Run("uses the faas instance", func(t *testing.T) {
+		res := resource.NewSchemaless(attribute.String("faas.instance", "instance-1"))
+		assert.Equal(t, "instance-1", instanceID(res))
+	})
+
+	t.Run("generates a stable id", func(t *testing.T) {
+		id := instanceID(resource.Empty())
+		assert.NotEmpty(t, id)
+		assert.Equal(t, id, instanceID(resource.Empty()))
+	})
+}
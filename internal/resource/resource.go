@@ -0,0 +1,136 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package resource // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// shared is the resource of a service, and the error of its detection, which are built once.
+type shared struct {
+	once     sync.Once
+	resource *resource.Resource
+	err      error
+}
+
+var (
+	mu sync.Mutex
+	// resources are the resources which are already built or being built, by service name.
+	resources = map[string]*shared{}
+)
+
+// Reset discards the resources which are already built.
+// This function is primarily intended for testing purposes.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	resources = map[string]*shared{}
+}
+
+// detectors returns the detectors of the platform of the process.
+var detectors = func() []resource.Detector {
+	return []resource.Detector{newKubernetesDetector(), newGCPDetector()}
+}
+
+// Get returns the resource of the given service, which is shared by the logs, the traces and the metrics,
+// so their correlation keys (e.g. `service.instance.id`) are identical.
+//
+// The resource is built once per service name, from (in order of precedence, from lowest to highest):
+//
+//   - the Kubernetes pod (see kubernetesDetector) and the GCP platform (see gcpDetector)
+//   - the telemetry SDK, the container and the host
+//   - a generated `service.instance.id` (see instanceID)
+//   - the `OTEL_RESOURCE_ATTRIBUTES` environment variable
+//   - the service name, which takes precedence over `OTEL_SERVICE_NAME`
+//
+// If any of the detectors fails, the partial resource is returned together with the error.
+//
+// The detection of a service (which may query the GCP metadata server) does not block the other services.
+func Get(ctx context.Context, service string) (*resource.Resource, error) {
+	mu.Lock()
+	s, ok := resources[service]
+	if !ok {
+		s = &shared{}
+		resources[service] = s
+	}
+	mu.Unlock()
+
+	s.once.Do(func() {
+		s.resource, s.err = build(ctx, service)
+	})
+
+	return s.resource, s.err
+}
+
+// build detects the resource of the given service.
+func build(ctx context.Context, service string) (*resource.Resource, error) {
+	detected, detectErr := resource.New(
+		ctx,
+		resource.WithDetectors(detectors()...),
+		resource.WithTelemetrySDK(),
+		resource.WithContainer(),
+		resource.WithHost(),
+	)
+	if detected == nil {
+		detected = resource.Empty()
+	}
+
+	fromEnv, envErr := resource.New(ctx, resource.WithFromEnv())
+	if fromEnv == nil {
+		fromEnv = resource.Empty()
+	}
+
+	attrs := []attribute.KeyValue{attribute.String(config.SERVICE_INTANCE_ID, instanceID(detected))}
+	res, mergeErr := resource.Merge(detected, resource.NewSchemaless(attrs...))
+	if mergeErr != nil {
+		return detected, errors.Join(detectErr, envErr, mergeErr)
+	}
+
+	res, mergeErr = resource.Merge(res, fromEnv)
+	if mergeErr != nil {
+		return res, errors.Join(detectErr, envErr, mergeErr)
+	}
+
+	if service != "" {
+		res, mergeErr = resource.Merge(res, resource.NewSchemaless(attribute.String(config.SERVICE_NAME, service)))
+	}
+
+	return res, errors.Join(detectErr, envErr, mergeErr)
+}
+
+// WithAttributes returns the shared resource of the given service, with the given attributes of a single signal.
+func WithAttributes(ctx context.Context, service string, attrs ...attribute.KeyValue) (*resource.Resource, error) {
+	res, err := Get(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource.Merge(res, resource.NewSchemaless(attrs...))
+}
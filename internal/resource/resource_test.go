@@ -0,0 +1,166 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package resource // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("OTEL_SERVICE_NAME", "env-service")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment=test,service.instance.id=instance-1")
+	Reset()
+	t.Cleanup(Reset)
+
+	res, err := Get(ctx, "test-service")
+	require.NoError(t, err)
+
+	service, _ := res.Set().Value(attribute.Key(config.SERVICE_NAME))
+	assert.Equal(t, "test-service", service.AsString())
+	instance, _ := res.Set().Value(attribute.Key(config.SERVICE_INTANCE_ID))
+	assert.Equal(t, "instance-1", instance.AsString())
+	environment, _ := res.Set().Value("deployment.environment")
+	assert.Equal(t, "test", environment.AsString())
+
+	// the resource is built once per service
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.instance.id=instance-2")
+	again, err := Get(ctx, "test-service")
+	require.NoError(t, err)
+	assert.Same(t, res, again)
+
+	// without a service name, the service name of the environment is used
+	other, err := Get(ctx, "")
+	require.NoError(t, err)
+	service, _ = other.Set().Value(attribute.Key(config.SERVICE_NAME))
+	assert.Equal(t, "env-service", service.AsString())
+}
+
+// blockingDetector counts its detections, and blocks them until it is released.
+type blockingDetector struct {
+	detections atomic.Int64
+	detecting  chan struct{}
+	release    chan struct{}
+}
+
+// Detect signals the detection, and waits for the release of the detector.
+func (d *blockingDetector) Detect(context.Context) (*resource.Resource, error) {
+	if d.detections.Add(1) == 1 {
+		close(d.detecting)
+	}
+	<-d.release
+	return resource.Empty(), nil
+}
+
+func TestGetConcurrently(t *testing.T) {
+	ctx := context.Background()
+	Reset()
+	t.Cleanup(Reset)
+
+	detector := &blockingDetector{detecting: make(chan struct{}), release: make(chan struct{})}
+	original := detectors
+	detectors = func() []resource.Detector { return []resource.Detector{detector} }
+	t.Cleanup(func() { detectors = original })
+
+	var wg sync.WaitGroup
+	results := make([]*resource.Resource, 2)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = Get(ctx, "slow-service")
+		}()
+	}
+	<-detector.detecting
+
+	// the detection of a service does not block the other services
+	detectors = func() []resource.Detector { return nil }
+	res, err := Get(ctx, "fast-service")
+	require.NoError(t, err)
+	assert.NotNil(t, res)
+
+	close(detector.release)
+	wg.Wait()
+
+	// the resource of a service is detected once
+	assert.Equal(t, int64(1), detector.detections.Load())
+	assert.Same(t, results[0], results[1])
+}
+
+func TestWithAttributes(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.instance.id=instance-1")
+	Reset()
+	t.Cleanup(Reset)
+
+	traces, err := WithAttributes(ctx, "test-service", attribute.String(config.EXPORTER_PROTOCOL, "grpc"))
+	require.NoError(t, err)
+	metrics, err := WithAttributes(ctx, "test-service", attribute.String(config.EXPORTER_PROTOCOL, "http/protobuf"))
+	require.NoError(t, err)
+
+	protocol, _ := traces.Set().Value(attribute.Key(config.EXPORTER_PROTOCOL))
+	assert.Equal(t, "grpc", protocol.AsString())
+	protocol, _ = metrics.Set().Value(attribute.Key(config.EXPORTER_PROTOCOL))
+	assert.Equal(t, "http/protobuf", protocol.AsString())
+
+	// the shared attributes are identical
+	tracesInstance, _ := traces.Set().Value(attribute.Key(config.SERVICE_INTANCE_ID))
+	metricsInstance, _ := metrics.Set().Value(attribute.Key(config.SERVICE_INTANCE_ID))
+	assert.Equal(t, "instance-1", tracesInstance.AsString())
+	assert.Equal(t, tracesInstance, metricsInstance)
+}
+
+func TestGetDetectsThePlatform(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "k8s.node.name=from-env")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	t.Setenv("K8S_POD_NAME", "api-7d9f")
+	t.Setenv("K8S_NAMESPACE_NAME", "payments")
+	t.Setenv("K8S_NODE_NAME", "node-1")
+	t.Setenv("K8S_CONTAINER_NAME", "main")
+	fakeMetadataServer(t, map[string]string{
+		"project/project-id":               "acme",
+		"instance/attributes/cluster-name": "prod",
+	})
+	Reset()
+	t.Cleanup(Reset)
+
+	res, err := Get(ctx, "test-service")
+	require.NoError(t, err)
+
+	attrs := attributes(res)
+	assert.Equal(t, "payments.api-7d9f.main", attrs[config.SERVICE_INTANCE_ID])
+	assert.Equal(t, "prod", attrs["k8s.cluster.name"])
+	assert.Equal(t, "gcp_kubernetes_engine", attrs["cloud.platform"])
+	// the environment takes precedence over the detected attributes
+	assert.Equal(t, "from-env", attrs["k8s.node.name"])
+}
@@ -0,0 +1,149 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package span // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/span"
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Classification describes how an error is recorded in a span.
+//
+// The error is always recorded as an event of the span. The classification decides whether
+// the error also sets the Error status of the span, and which attributes are added to the span.
+type Classification struct {
+	// Failure is whether the error sets the Error status of the span.
+	// If false, the error is only recorded as an event, so it does not count in the error rates.
+	Failure bool
+	// Code is the error code (e.g. NOT_FOUND), added to the span as the error.code attribute if not empty.
+	Code string
+	// Retryable is whether the operation can be retried, added to the span as the error.retryable attribute if true.
+	Retryable bool
+	// Attributes are additional attributes added to the span.
+	Attributes []attribute.KeyValue
+}
+
+// attributes returns the attributes added to the span for the classification.
+func (c Classification) attributes() []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(c.Attributes)+2)
+	if c.Code != "" {
+		attrs = append(attrs, attribute.String(config.ERROR_CODE, c.Code))
+	}
+	if c.Retryable {
+		attrs = append(attrs, attribute.Bool(config.ERROR_RETRYABLE, true))
+	}
+
+	return append(attrs, c.Attributes...)
+}
+
+// ClassifiedError is implemented by the errors which describe how they are recorded in a span.
+//
+// The default classifier looks for it in the whole chain of wrapped errors.
+type ClassifiedError interface {
+	error
+	SpanClassification() Classification
+}
+
+// classifiedError is an error wrapped with its classification.
+type classifiedError struct {
+	error
+	classification Classification
+}
+
+// SpanClassification returns the classification of the error.
+func (e classifiedError) SpanClassification() Classification {
+	return e.classification
+}
+
+// Unwrap returns the wrapped error.
+func (e classifiedError) Unwrap() error {
+	return e.error
+}
+
+// NewClassifiedError wraps the error with the given classification, so the error type
+// does not have to implement ClassifiedError. It returns nil if the error is nil.
+func NewClassifiedError(err error, classification Classification) error {
+	if err == nil {
+		return nil
+	}
+
+	return classifiedError{error: err, classification: classification}
+}
+
+// ErrorClassifier decides how the errors are recorded in the spans.
+type ErrorClassifier interface {
+	Classify(err error) Classification
+}
+
+// ErrorClassifierFunc is a function which implements ErrorClassifier.
+type ErrorClassifierFunc func(err error) Classification
+
+// Classify calls the function.
+func (f ErrorClassifierFunc) Classify(err error) Classification {
+	return f(err)
+}
+
+// DefaultClassification returns the classification of the error by the default classifier:
+//
+// - the classification of the first ClassifiedError in the chain of wrapped errors
+//
+// - context.Canceled is not a failure, since the caller gave up on the operation
+//
+// - any other error is a failure
+func DefaultClassification(err error) Classification {
+	var classified ClassifiedError
+	if errors.As(err, &classified) {
+		return classified.SpanClassification()
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return Classification{Failure: false}
+	}
+
+	return Classification{Failure: true}
+}
+
+// classifier is the classifier used by EndWithError.
+var classifier atomic.Pointer[ErrorClassifier]
+
+// SetErrorClassifier replaces the classifier used by EndWithError.
+// If the classifier is nil, the default classifier is restored (see DefaultClassification).
+func SetErrorClassifier(c ErrorClassifier) {
+	if c == nil {
+		classifier.Store(nil)
+		return
+	}
+	classifier.Store(&c)
+}
+
+// classify returns the classification of the error by the current classifier.
+func classify(err error) Classification {
+	if c := classifier.Load(); c != nil {
+		return (*c).Classify(err)
+	}
+	return DefaultClassification(err)
+}
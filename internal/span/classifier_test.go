@@ -0,0 +1,144 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package span
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	testhelpers "github.com/FLYR-Open-Source/flyr-lib-go/pkg/testhelpers/monitoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// notFoundError is an error which classifies itself.
+type notFoundError struct{}
+
+func (notFoundError) Error() string {
+	return "not found"
+}
+
+func (notFoundError) SpanClassification() Classification {
+	return Classification{Code: "NOT_FOUND"}
+}
+
+func TestDefaultClassification(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Classification
+	}{
+		{
+			name:     "with error",
+			err:      errors.New("test error"),
+			expected: Classification{Failure: true},
+		},
+		{
+			name:     "with canceled context",
+			err:      fmt.Errorf("query: %w", context.Canceled),
+			expected: Classification{Failure: false},
+		},
+		{
+			name:     "with deadline exceeded",
+			err:      context.DeadlineExceeded,
+			expected: Classification{Failure: true},
+		},
+		{
+			name:     "with classified error",
+			err:      fmt.Errorf("load booking: %w", notFoundError{}),
+			expected: Classification{Code: "NOT_FOUND"},
+		},
+		{
+			name:     "with wrapped classification",
+			err:      NewClassifiedError(errors.New("unavailable"), Classification{Failure: true, Retryable: true}),
+			expected: Classification{Failure: true, Retryable: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DefaultClassification(tt.err))
+		})
+	}
+}
+
+func TestNewClassifiedError(t *testing.T) {
+	err := errors.New("test error")
+
+	classified := NewClassifiedError(err, Classification{Code: "INVALID"})
+	require.ErrorIs(t, classified, err)
+	assert.EqualError(t, classified, "test error")
+
+	require.NoError(t, NewClassifiedError(nil, Classification{}))
+}
+
+func TestEndWithErrorClassification(t *testing.T) {
+	t.Run("records a canceled context as an event only", func(t *testing.T) {
+		_, fakeSpan := testhelpers.GetFakeSpan(context.Background())
+
+		span := Span{Span: &fakeSpan}
+		span.EndWithError(context.Canceled)
+
+		require.ErrorIs(t, fakeSpan.FakeRecordedError.Error, context.Canceled)
+		assert.Equal(t, codes.Unset, fakeSpan.FakeStatus.Code)
+	})
+
+	t.Run("adds the attributes of the classification", func(t *testing.T) {
+		_, fakeSpan := testhelpers.GetFakeSpan(context.Background())
+		err := NewClassifiedError(errors.New("unavailable"), Classification{
+			Failure:    true,
+			Code:       "UNAVAILABLE",
+			Retryable:  true,
+			Attributes: []attribute.KeyValue{attribute.String("db.system", "postgresql")},
+		})
+
+		span := Span{Span: &fakeSpan}
+		span.EndWithError(err)
+
+		assert.Equal(t, codes.Error, fakeSpan.FakeStatus.Code)
+		assert.Equal(t, []attribute.KeyValue{
+			attribute.String("error.code", "UNAVAILABLE"),
+			attribute.Bool("error.retryable", true),
+			attribute.String("db.system", "postgresql"),
+		}, fakeSpan.FakeAttributes)
+	})
+
+	t.Run("uses the custom classifier", func(t *testing.T) {
+		SetErrorClassifier(ErrorClassifierFunc(func(err error) Classification {
+			return Classification{Failure: false, Code: "IGNORED"}
+		}))
+		t.Cleanup(func() { SetErrorClassifier(nil) })
+
+		_, fakeSpan := testhelpers.GetFakeSpan(context.Background())
+
+		span := Span{Span: &fakeSpan}
+		span.EndWithError(errors.New("test error"))
+
+		assert.Equal(t, codes.Unset, fakeSpan.FakeStatus.Code)
+		assert.Equal(t, []attribute.KeyValue{attribute.String("error.code", "IGNORED")}, fakeSpan.FakeAttributes)
+	})
+}
@@ -23,6 +23,8 @@
 package span // import "github.com/FLYR-Open-Source/flyr-lib-go/internal/span"
 
 import (
+	"fmt"
+
 	otelcodes "go.opentelemetry.io/otel/codes"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -34,19 +36,36 @@ type Span struct {
 	oteltrace.Span
 }
 
-// EndWithError ends the span by updating the status to Error and recording the error
+// EndWithError ends the span by recording the error, and updating the status to Error
+// if the error is classified as a failure (see SetErrorClassifier)
 func (s *Span) EndWithError(err error) {
 	if s.Span == nil {
 		return
 	}
 
 	if err != nil {
-		s.SetStatus(otelcodes.Error, err.Error())
-		s.RecordError(err)
+		s.SetError(err)
 	}
 	s.End()
 }
 
+// SetError records the error, and updates the status to Error if the error is classified
+// as a failure (see SetErrorClassifier), without ending the span.
+func (s *Span) SetError(err error) {
+	if s.Span == nil {
+		return
+	}
+
+	classification := classify(err)
+	if classification.Failure {
+		s.SetStatus(otelcodes.Error, err.Error())
+	}
+	if attrs := classification.attributes(); len(attrs) > 0 {
+		s.SetAttributes(attrs...)
+	}
+	s.RecordError(err)
+}
+
 // EndSuccessfully ends the span by updating the status to Ok
 func (s *Span) EndSuccessfully() {
 	if s.Span == nil {
@@ -56,3 +75,24 @@ func (s *Span) EndSuccessfully() {
 	s.SetStatus(otelcodes.Ok, "")
 	s.End()
 }
+
+// EndWithPanic ends the span by updating the status to Error and recording the recovered panic
+// with the stack trace of the panicking goroutine.
+//
+// It must be called from the deferred function which recovered the panic, so the stack trace still
+// includes the function which panicked.
+func (s *Span) EndWithPanic(recovered any) {
+	if s.Span == nil {
+		return
+	}
+
+	err, ok := recovered.(error)
+	if !ok {
+		err = fmt.Errorf("%v", recovered)
+	}
+	err = fmt.Errorf("panic: %w", err)
+
+	s.SetStatus(otelcodes.Error, err.Error())
+	s.RecordError(err, oteltrace.WithStackTrace(true))
+	s.End()
+}
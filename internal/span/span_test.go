@@ -72,6 +72,30 @@ func TestEndSuccessfully(t *testing.T) {
 	assert.Equal(t, "", fakeSpan.FakeStatus.Description)
 }
 
+func TestEndWithPanic(t *testing.T) {
+	t.Run("With Value", func(t *testing.T) {
+		_, fakeSpan := testhelpers.GetFakeSpan(context.Background())
+
+		span := Span{Span: &fakeSpan}
+		span.EndWithPanic("boom")
+
+		require.EqualError(t, fakeSpan.FakeRecordedError.Error, "panic: boom")
+		assert.Equal(t, codes.Error, fakeSpan.FakeStatus.Code)
+		assert.Equal(t, "panic: boom", fakeSpan.FakeStatus.Description)
+	})
+
+	t.Run("With Error", func(t *testing.T) {
+		_, fakeSpan := testhelpers.GetFakeSpan(context.Background())
+		err := errors.New("test error")
+
+		span := Span{Span: &fakeSpan}
+		span.EndWithPanic(err)
+
+		require.ErrorIs(t, fakeSpan.FakeRecordedError.Error, err)
+		assert.Equal(t, "panic: test error", fakeSpan.FakeStatus.Description)
+	})
+}
+
 func TestEndingZeroSpanDoesntPanic(t *testing.T) {
 	defer func() {
 		require.Nil(t, recover())
@@ -80,4 +104,5 @@ func TestEndingZeroSpanDoesntPanic(t *testing.T) {
 	s := Span{}
 	s.EndSuccessfully()
 	s.EndWithError(nil)
+	s.EndWithPanic("boom")
 }
@@ -75,6 +75,49 @@ func GetCallerName(numofSkippedFrames int) Caller {
 	}
 }
 
+// GetCallerOutside retrieves the first caller in the call stack that does not belong to
+// any of the given function prefixes.
+//
+// This function is useful when the number of frames between the caller and the logger
+// is not fixed, e.g. when logging through the standard "log" package. The number of frames
+// to skip before the search starts is specified by numofSkippedFrames, as in GetCallerName.
+// The prefixes are matched against the fully qualified function name (e.g. "log." matches
+// every function of the standard log package).
+//
+// Returns an empty Caller if every frame matches one of the prefixes.
+func GetCallerOutside(numofSkippedFrames int, prefixes ...string) Caller {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(numofSkippedFrames+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !HasAnyPrefix(frame.Function, prefixes) {
+			namespace, functionName := splitFunctionName(frame.Function)
+			return Caller{
+				FilePath:     frame.File,
+				LineNumber:   frame.Line,
+				FunctionName: functionName,
+				Namespace:    namespace,
+			}
+		}
+
+		if !more {
+			return Caller{}
+		}
+	}
+}
+
+// HasAnyPrefix returns true if s starts with any of the given prefixes.
+func HasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // splitFunctionName splits full function name into namespace and function name
 // if the passed function name does not contain a namespace, then it returns an empty string for the namespace
 // and the passed function name.
@@ -127,3 +127,25 @@ func TestCallerSpanAttributes(t *testing.T) {
 
 	assert.Equal(t, expected, spanAttrs)
 }
+
+func TestGetCallerOutside(t *testing.T) {
+	t.Run("Without prefixes returns the direct caller", func(t *testing.T) {
+		caller := GetCallerOutside(1)
+
+		assert.Contains(t, caller.FilePath, "caller_test.go")
+		assert.Positive(t, caller.LineNumber)
+	})
+
+	t.Run("Skips the frames matching the prefixes", func(t *testing.T) {
+		caller := GetCallerOutside(1, "github.com/FLYR-Open-Source/flyr-lib-go/internal/utils.")
+
+		assert.Contains(t, caller.FilePath, "src/testing/testing.go")
+		assert.Equal(t, "tRunner", caller.FunctionName)
+	})
+
+	t.Run("Returns an empty caller when every frame matches", func(t *testing.T) {
+		caller := GetCallerOutside(1, "")
+
+		assert.Equal(t, Caller{}, caller)
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"log/slog"
 
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	internalLogger "github.com/FLYR-Open-Source/flyr-lib-go/internal/logger"
 	internalUtils "github.com/FLYR-Open-Source/flyr-lib-go/internal/utils"
 )
 
@@ -40,6 +41,8 @@ type Attribute struct {
 	err               error
 	metadata          []interface{}
 	injectAttrsToSpan bool
+	// caller overrides the caller retrieved from the call stack
+	caller *internalUtils.Caller
 }
 
 // WithError sets the error in the attribute
@@ -60,23 +63,52 @@ func (a *Attribute) WithOutInjectingAttrsToSpan() *Attribute {
 	return a
 }
 
+// withCaller sets the caller of the log, instead of retrieving it from the call stack.
+//
+// It is used by the bridges, where the depth of the caller is not fixed.
+func (a *Attribute) withCaller(caller internalUtils.Caller) *Attribute {
+	a.caller = &caller
+	return a
+}
+
 // Get returns the log attributes
+//
+// In flat metadata mode, the metadata attributes are returned at the top level
+// (after resolving any collision with the reserved keys) instead of in the "metadata" group.
 func (a *Attribute) Get(ctx context.Context) []slog.Attr {
-	metadata := slog.Group(config.LOG_METADATA_KEY, a.metadata...)
+	s := currentSettings()
+
+	metadata := s.limits.LimitAttr(slog.Group(config.LOG_METADATA_KEY, a.metadata...))
+	metadataAttrs := []slog.Attr{metadata}
+	if s.flatMetadata {
+		metadataAttrs = internalLogger.ResolveCollisions(metadata.Value.Group(), s.collisionPolicy)
+		// an empty key injects the attributes to the span without a prefix
+		metadata = slog.Attr{Value: slog.GroupValue(metadataAttrs...)}
+	}
+
 	if a.injectAttrsToSpan {
 		injectAttrsToSpan(ctx, metadata)
 	}
 
-	caller := internalUtils.GetCallerName(callerDepth)
+	var caller internalUtils.Caller
+	if a.caller != nil {
+		caller = *a.caller
+	} else {
+		caller = internalUtils.GetCallerName(callerDepth)
+	}
 	callerAttrs := caller.LogAttributes()
 
-	attrs := append(callerAttrs, metadata)
+	attrs := append(callerAttrs, metadataAttrs...)
 
 	var errorMessage slog.Attr
 	if a.err != nil {
 		errorMessage = slog.String(config.LOG_ERROR_KEY, a.err.Error())
 		setErroredSpan(ctx, a.err) // Set spans as errored
 		attrs = append(attrs, errorMessage)
+
+		if s.errorReporting {
+			attrs = append(attrs, internalLogger.ErrorReportingAttrs(a.err, caller, s.serviceContext)...)
+		}
 	}
 
 	return attrs
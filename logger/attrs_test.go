@@ -25,10 +25,12 @@ package logger
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"testing"
 
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	internalLogger "github.com/FLYR-Open-Source/flyr-lib-go/internal/logger"
 	"github.com/stretchr/testify/assert"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
@@ -88,6 +90,47 @@ func TestGetAttributes(t *testing.T) {
 		assert.Equal(t, err.Error(), errorMessage.Value.String())
 	})
 
+	t.Run("With limited metadata", func(t *testing.T) {
+		settings.Store(&recordSettings{limits: internalLogger.Limits{MaxValueLength: 3}})
+		t.Cleanup(func() { settings.Store(nil) })
+
+		attrs := NewAttribute().
+			WithMetadata(args...).
+			Get(context.Background())
+
+		metadata := attrs[4]
+		assert.Equal(t, "[key1=val"+config.LOG_TRUNCATED_MARKER+" key2=val"+config.LOG_TRUNCATED_MARKER+"]", metadata.Value.String())
+	})
+
+	t.Run("With flat metadata", func(t *testing.T) {
+		settings.Store(&recordSettings{flatMetadata: true, collisionPolicy: internalLogger.CollisionPrefix})
+		t.Cleanup(func() { settings.Store(nil) })
+
+		attrs := NewAttribute().
+			WithMetadata("key1", "value1", config.SERVICE_NAME, "other-service").
+			Get(context.Background())
+
+		assert.Len(t, attrs, 6)
+		assert.Equal(t, "key1", attrs[4].Key)
+		assert.Equal(t, "value1", attrs[4].Value.String())
+		assert.Equal(t, config.LOG_METADATA_KEY+"."+config.SERVICE_NAME, attrs[5].Key)
+		assert.Equal(t, "other-service", attrs[5].Value.String())
+	})
+
+	t.Run("With an error formatted for Error Reporting", func(t *testing.T) {
+		settings.Store(&recordSettings{errorReporting: true, serviceContext: internalLogger.ServiceContext{Service: "test-service"}})
+		t.Cleanup(func() { settings.Store(nil) })
+
+		err := errors.New("test error")
+		attrs := NewAttribute().
+			WithError(err).
+			Get(context.Background())
+
+		assert.Len(t, attrs, 10)
+		assert.Equal(t, config.ERROR_REPORTING_TYPE, attrs[6].Value.String())
+		assert.Regexp(t, `\n\ngoroutine \d+ \[running\]:\n`, attrs[7].Value.String())
+	})
+
 	t.Run("Without extra metadata", func(t *testing.T) {
 		attrs := NewAttribute().Get(context.Background())
 
@@ -98,3 +141,22 @@ func TestGetAttributes(t *testing.T) {
 		assert.Equal(t, "[]", metadata.Value.String())
 	})
 }
+
+func TestGetAttributesWhileInitializing(t *testing.T) {
+	t.Cleanup(func() { settings.Store(nil) })
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 10 {
+			InitLogger()
+		}
+	}()
+
+	for range 100 {
+		attrs := NewAttribute().WithMetadata("key", "value").Get(context.Background())
+		assert.NotEmpty(t, attrs)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,313 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger // import "github.com/FLYR-Open-Source/flyr-lib-go/logger"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	internalUtils "github.com/FLYR-Open-Source/flyr-lib-go/internal/utils"
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// The depth of the caller in the stack trace for the bridges
+	// (the bridge method, the bridge log function and GetCallerName itself).
+	bridgeCallerDepth = 3
+	// logrNameKey is the metadata key holding the name of a logr.Logger
+	logrNameKey = "logger"
+)
+
+// ErrLoggerNotInitialized is returned when the standard log package is redirected before InitLogger.
+var ErrLoggerNotInitialized = errors.New("logger not initialized")
+
+// logWithCaller logs a message with the given caller through the default logger.
+//
+// The log record has the same schema as the records produced by Debug, Info, Warn and Error.
+func logWithCaller(ctx context.Context, level slog.Level, message string, err error, caller internalUtils.Caller, args ...interface{}) {
+	l := slog.Default()
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
+	attribute := NewAttribute().
+		WithMetadata(args...).
+		withCaller(caller)
+	if level <= slog.LevelDebug {
+		attribute.WithOutInjectingAttrsToSpan()
+	}
+	if err != nil {
+		attribute.WithError(err)
+	}
+
+	l.LogAttrs(ctx, level, message, attribute.Get(ctx)...)
+}
+
+// logrSink is a logr.LogSink that routes the logs to the default logger.
+type logrSink struct {
+	ctx       context.Context
+	name      string
+	values    []interface{}
+	callDepth int
+}
+
+// logrLevel converts a logr verbosity level to a slog.Level.
+//
+// The verbosities 0 and 1 are mapped to info, while every higher verbosity is mapped to debug.
+// The verbosity 1 is kept at info, because the OpenTelemetry SDK logs its warnings with it.
+func logrLevel(level int) slog.Level {
+	if level > 1 {
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// Init receives the number of frames logr adds between the caller and the sink.
+func (s *logrSink) Init(info logr.RuntimeInfo) {
+	s.callDepth += info.CallDepth
+}
+
+// Enabled returns true if the default logger handles the level.
+func (s *logrSink) Enabled(level int) bool {
+	return slog.Default().Enabled(s.ctx, logrLevel(level))
+}
+
+// Info logs a non-error message with the given key/value pairs as metadata.
+func (s *logrSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.log(logrLevel(level), msg, nil, keysAndValues)
+}
+
+// Error logs an error, with the given message and key/value pairs as metadata.
+func (s *logrSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.log(slog.LevelError, msg, err, keysAndValues)
+}
+
+// log logs the message with the accumulated values and name of the sink.
+func (s *logrSink) log(level slog.Level, msg string, err error, keysAndValues []interface{}) {
+	caller := internalUtils.GetCallerName(bridgeCallerDepth + s.callDepth)
+
+	args := make([]interface{}, 0, len(s.values)+len(keysAndValues)+2)
+	if s.name != "" {
+		args = append(args, logrNameKey, s.name)
+	}
+	args = append(args, s.values...)
+	args = append(args, keysAndValues...)
+
+	logWithCaller(s.ctx, level, msg, err, caller, args...)
+}
+
+// WithValues returns a new sink with additional key/value pairs.
+func (s *logrSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	clone := *s
+	clone.values = append(append(make([]interface{}, 0, len(s.values)+len(keysAndValues)), s.values...), keysAndValues...)
+	return &clone
+}
+
+// WithName returns a new sink with the specified name appended.
+func (s *logrSink) WithName(name string) logr.LogSink {
+	clone := *s
+	if clone.name == "" {
+		clone.name = name
+	} else {
+		clone.name = clone.name + "/" + name
+	}
+	return &clone
+}
+
+// WithCallDepth returns a new sink that offsets the call stack by the specified number of frames.
+func (s *logrSink) WithCallDepth(depth int) logr.LogSink {
+	clone := *s
+	clone.callDepth += depth
+	return &clone
+}
+
+// NewLogr returns a logr.Logger that routes the logs to the logger initialised by InitLogger.
+//
+// The given context is used for every log, so the logs are correlated with the span in the context
+// (if any). The verbosity level 0 is logged as info and every higher verbosity as debug.
+// The key/value pairs are added in the metadata and the name of the logr.Logger in the metadata key "logger".
+func NewLogr(ctx context.Context) logr.Logger {
+	return logr.New(&logrSink{ctx: ctx})
+}
+
+// SetOtelLogger routes the internal logs of OpenTelemetry to the logger initialised by InitLogger.
+func SetOtelLogger() {
+	otel.SetLogger(NewLogr(context.Background()).WithName("otel"))
+}
+
+// stdLogWriter is an io.Writer for the standard log package that routes the logs to the default logger.
+type stdLogWriter struct {
+	ctx   context.Context
+	level slog.Level
+}
+
+// Write logs the given line as the message of a log record.
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	// skip this method and the frames of the log package to find the caller
+	caller := internalUtils.GetCallerOutside(2, "log.")
+	message := strings.TrimSuffix(string(p), "\n")
+	logWithCaller(w.ctx, w.level, message, nil, caller)
+	return len(p), nil
+}
+
+// NewStdLogger returns a *log.Logger that routes the logs to the logger initialised by InitLogger,
+// using the given level.
+//
+// The given context is used for every log, so the logs are correlated with the span in the context (if any).
+func NewStdLogger(ctx context.Context, level slog.Level) *log.Logger {
+	return log.New(&stdLogWriter{ctx: ctx, level: level}, "", 0)
+}
+
+// RedirectStdLog routes the output of the standard log package to the logger initialised by InitLogger,
+// using the given level.
+//
+// It returns ErrLoggerNotInitialized if it is called before InitLogger: the default slog logger writes
+// through the standard log package until then, so the logs would be routed back to themselves.
+func RedirectStdLog(level slog.Level) error {
+	if settings.Load() == nil {
+		return ErrLoggerNotInitialized
+	}
+
+	log.SetFlags(0)
+	log.SetPrefix("")
+	log.SetOutput(&stdLogWriter{ctx: context.Background(), level: level})
+	return nil
+}
+
+// SugaredLogger is a logger with a zap-like sugared API.
+//
+// It is meant for code that is written against the zap.SugaredLogger methods.
+// Every log has the same schema as the logs produced by Debug, Info, Warn and Error.
+type SugaredLogger struct {
+	ctx  context.Context
+	args []interface{}
+}
+
+// Sugar returns a SugaredLogger that uses the given context for every log.
+func Sugar(ctx context.Context) *SugaredLogger {
+	return &SugaredLogger{ctx: ctx}
+}
+
+// With returns a new SugaredLogger with the given key/value pairs added to every log.
+func (s *SugaredLogger) With(keysAndValues ...interface{}) *SugaredLogger {
+	return &SugaredLogger{
+		ctx:  s.ctx,
+		args: append(append(make([]interface{}, 0, len(s.args)+len(keysAndValues)), s.args...), keysAndValues...),
+	}
+}
+
+// Debugw logs a message at the debug level with the given key/value pairs as metadata.
+func (s *SugaredLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	s.log(slog.LevelDebug, msg, nil, keysAndValues)
+}
+
+// Infow logs a message at the info level with the given key/value pairs as metadata.
+func (s *SugaredLogger) Infow(msg string, keysAndValues ...interface{}) {
+	s.log(slog.LevelInfo, msg, nil, keysAndValues)
+}
+
+// Warnw logs a message at the warn level with the given key/value pairs as metadata.
+func (s *SugaredLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	s.log(slog.LevelWarn, msg, nil, keysAndValues)
+}
+
+// Errorw logs a message at the error level with the given key/value pairs as metadata.
+//
+// An error value passed with the key "error" is logged as the error of the log record.
+func (s *SugaredLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	s.log(slog.LevelError, msg, nil, keysAndValues)
+}
+
+// Debugf logs a formatted message at the debug level.
+func (s *SugaredLogger) Debugf(template string, args ...interface{}) {
+	s.log(slog.LevelDebug, fmt.Sprintf(template, args...), nil, nil)
+}
+
+// Infof logs a formatted message at the info level.
+func (s *SugaredLogger) Infof(template string, args ...interface{}) {
+	s.log(slog.LevelInfo, fmt.Sprintf(template, args...), nil, nil)
+}
+
+// Warnf logs a formatted message at the warn level.
+func (s *SugaredLogger) Warnf(template string, args ...interface{}) {
+	s.log(slog.LevelWarn, fmt.Sprintf(template, args...), nil, nil)
+}
+
+// Errorf logs a formatted message at the error level.
+//
+// If one of the arguments is an error, it is logged as the error of the log record.
+func (s *SugaredLogger) Errorf(template string, args ...interface{}) {
+	var err error
+	for _, arg := range args {
+		if e, ok := arg.(error); ok {
+			err = e
+			break
+		}
+	}
+	s.log(slog.LevelError, fmt.Sprintf(template, args...), err, nil)
+}
+
+// log logs the message with the given error and the accumulated and given key/value pairs.
+//
+// If the error is nil, it is extracted from the key/value pairs.
+func (s *SugaredLogger) log(level slog.Level, msg string, err error, keysAndValues []interface{}) {
+	caller := internalUtils.GetCallerName(bridgeCallerDepth)
+	if err == nil {
+		err, keysAndValues = extractError(keysAndValues)
+	}
+
+	args := make([]interface{}, 0, len(s.args)+len(keysAndValues))
+	args = append(args, s.args...)
+	args = append(args, keysAndValues...)
+
+	logWithCaller(s.ctx, level, msg, err, caller, args...)
+}
+
+// extractError removes the first key/value pair with the key "error" and an error value,
+// and returns the error and the remaining key/value pairs.
+func extractError(keysAndValues []interface{}) (error, []interface{}) {
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok || key != config.LOG_ERROR_KEY {
+			continue
+		}
+
+		err, ok := keysAndValues[i+1].(error)
+		if !ok {
+			continue
+		}
+
+		remaining := make([]interface{}, 0, len(keysAndValues)-2)
+		remaining = append(remaining, keysAndValues[:i]...)
+		remaining = append(remaining, keysAndValues[i+2:]...)
+		return err, remaining
+	}
+
+	return nil, keysAndValues
+}
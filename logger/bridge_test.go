@@ -0,0 +1,192 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureLogs sets a JSON logger writing to a buffer as the default logger for the test.
+func captureLogs(t *testing.T, level slog.Level) *bytes.Buffer {
+	output := &bytes.Buffer{}
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: level})))
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	})
+	return output
+}
+
+func decodeLog(t *testing.T, output *bytes.Buffer) map[string]interface{} {
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	return record
+}
+
+func TestLogrBridge(t *testing.T) {
+	t.Run("Info logs with metadata and caller", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		l := NewLogr(context.Background()).WithName("controller").WithValues("key1", "value1")
+		l.Info("logr message", "key2", 2)
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "INFO", record["level"])
+		assert.Equal(t, "logr message", record["msg"])
+		assert.Contains(t, record[config.FILE_PATH], "bridge_test.go")
+		assert.Equal(t, map[string]interface{}{"logger": "controller", "key1": "value1", "key2": float64(2)}, record[config.LOG_METADATA_KEY])
+	})
+
+	t.Run("Verbose logs are debug logs", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		NewLogr(context.Background()).V(2).Info("verbose message")
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "DEBUG", record["level"])
+	})
+
+	t.Run("Verbose logs are disabled with info level", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelInfo)
+
+		l := NewLogr(context.Background())
+		l.V(2).Info("verbose message")
+
+		assert.False(t, l.V(2).Enabled())
+		assert.Empty(t, output.String())
+	})
+
+	t.Run("Verbosity 1 logs are info logs", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelInfo)
+
+		l := NewLogr(context.Background())
+		// the OpenTelemetry SDK logs its warnings with the verbosity 1
+		l.V(1).Info("warning message")
+
+		assert.True(t, l.V(1).Enabled())
+		record := decodeLog(t, output)
+		assert.Equal(t, "INFO", record["level"])
+	})
+
+	t.Run("Error logs with the error", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		NewLogr(context.Background()).Error(errors.New("test error"), "logr error")
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "ERROR", record["level"])
+		assert.Equal(t, "test error", record[config.LOG_ERROR_KEY])
+		assert.Contains(t, record[config.FILE_PATH], "bridge_test.go")
+	})
+}
+
+func TestStdLogBridge(t *testing.T) {
+	t.Run("NewStdLogger", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		NewStdLogger(context.Background(), slog.LevelWarn).Printf("std %s", "message")
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "WARN", record["level"])
+		assert.Equal(t, "std message", record["msg"])
+		assert.Contains(t, record[config.FILE_PATH], "bridge_test.go")
+	})
+
+	t.Run("RedirectStdLog", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+		settings.Store(&recordSettings{})
+		t.Cleanup(func() { settings.Store(nil) })
+
+		require.NoError(t, RedirectStdLog(slog.LevelInfo))
+		log.Println("redirected message")
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "INFO", record["level"])
+		assert.Equal(t, "redirected message", record["msg"])
+		assert.Contains(t, record[config.FILE_PATH], "bridge_test.go")
+	})
+
+	t.Run("RedirectStdLog before InitLogger", func(t *testing.T) {
+		settings.Store(nil)
+
+		require.ErrorIs(t, RedirectStdLog(slog.LevelInfo), ErrLoggerNotInitialized)
+		assert.Equal(t, os.Stderr, log.Writer())
+	})
+}
+
+func TestSugaredLogger(t *testing.T) {
+	t.Run("Infow logs with metadata and caller", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		Sugar(context.Background()).With("key1", "value1").Infow("sugared message", "key2", "value2")
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "INFO", record["level"])
+		assert.Equal(t, "sugared message", record["msg"])
+		assert.Contains(t, record[config.FILE_PATH], "bridge_test.go")
+		assert.Equal(t, map[string]interface{}{"key1": "value1", "key2": "value2"}, record[config.LOG_METADATA_KEY])
+	})
+
+	t.Run("Errorw extracts the error", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		Sugar(context.Background()).Errorw("sugared error", "error", errors.New("test error"), "key", "value")
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "ERROR", record["level"])
+		assert.Equal(t, "test error", record[config.LOG_ERROR_KEY])
+		assert.Equal(t, map[string]interface{}{"key": "value"}, record[config.LOG_METADATA_KEY])
+	})
+
+	t.Run("Errorf formats the message and extracts the error", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		Sugar(context.Background()).Errorf("failed: %v", errors.New("test error"))
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "failed: test error", record["msg"])
+		assert.Equal(t, "test error", record[config.LOG_ERROR_KEY])
+		assert.Contains(t, record[config.FILE_PATH], "bridge_test.go")
+	})
+
+	t.Run("Debugf is not logged with info level", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelInfo)
+
+		Sugar(context.Background()).Debugf("debug %d", 1)
+
+		assert.Empty(t, output.String())
+	})
+}
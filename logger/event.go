@@ -0,0 +1,274 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger // import "github.com/FLYR-Open-Source/flyr-lib-go/logger"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/span"
+	internalUtils "github.com/FLYR-Open-Source/flyr-lib-go/internal/utils"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// The depth of the caller in the stack trace for the events
+	eventCallerDepth = 2
+	// eventTagName is the struct tag used to describe the fields of an event
+	eventTagName = "log"
+	// eventCounterName is the name of the counter incremented for every event
+	eventCounterName = "log.events"
+	// instrumentationName is the instrumentation scope name of the logger
+	instrumentationName = "github.com/FLYR-Open-Source/flyr-lib-go/logger"
+)
+
+// Errors
+var (
+	// ErrInvalidEvent is returned when the event data is not a struct (or a pointer to a struct).
+	ErrInvalidEvent = errors.New("event data must be a struct")
+	// ErrMissingEventField is returned when a required field of the event has the zero value.
+	ErrMissingEventField = errors.New("missing required event field")
+)
+
+var (
+	eventCounter     metric.Int64Counter
+	eventCounterOnce sync.Once
+)
+
+// getEventCounter returns the counter incremented for every event.
+//
+// The counter is created once from the global MeterProvider. If the MeterProvider is set later,
+// the counter is delegated to it.
+func getEventCounter() metric.Int64Counter {
+	eventCounterOnce.Do(func() {
+		meter := otel.GetMeterProvider().Meter(
+			instrumentationName,
+			metric.WithInstrumentationVersion(version.Version()),
+		)
+		eventCounter, _ = meter.Int64Counter(
+			eventCounterName,
+			metric.WithDescription("The number of domain events logged, per event name."),
+			metric.WithUnit("{event}"),
+		)
+	})
+	return eventCounter
+}
+
+// Event is a named business event (e.g. booking created) logged with typed data.
+//
+// The data must be a struct. Its exported fields are added to the metadata of the log record,
+// using the `log` struct tag to control the serialisation:
+//
+//	type BookingCreated struct {
+//		BookingID string `log:"booking_id,required"`
+//		Amount    int    `log:"amount,omitempty"`
+//		Internal  string `log:"-"`
+//	}
+//
+// The first tag option is the key (the field name is used if empty), "required" fails the log
+// if the field has the zero value, "omitempty" skips the field if it has the zero value and "-"
+// always skips the field.
+type Event struct {
+	name         string
+	data         interface{}
+	level        slog.Level
+	recordOnSpan bool
+	count        bool
+}
+
+// NewEvent creates a new event with the given name and data.
+//
+// The event is logged at the info level by default.
+func NewEvent(name string, data interface{}) *Event {
+	return &Event{name: name, data: data, level: slog.LevelInfo}
+}
+
+// WithLevel sets the level the event is logged at
+func (e *Event) WithLevel(level slog.Level) *Event {
+	e.level = level
+	return e
+}
+
+// WithSpanEvent records the event on the span retrieved from the context
+func (e *Event) WithSpanEvent() *Event {
+	e.recordOnSpan = true
+	return e
+}
+
+// WithCounter increments the "log.events" counter, with the event name as an attribute
+func (e *Event) WithCounter() *Event {
+	e.count = true
+	return e
+}
+
+// Log validates and logs the event.
+//
+// The message of the log record is the event name, which is also added in the key "event.name".
+// It returns an error (and nothing is logged) if the data is not a struct or a required field is missing.
+func (e *Event) Log(ctx context.Context) error {
+	fields, err := eventFields(e.data)
+	if err != nil {
+		return fmt.Errorf("event %q: %w", e.name, err)
+	}
+
+	caller := internalUtils.GetCallerName(eventCallerDepth)
+
+	l := slog.Default()
+	if l.Enabled(ctx, e.level) {
+		args := make([]interface{}, 0, len(fields))
+		for _, f := range fields {
+			args = append(args, slog.Any(f.key, f.value.Interface()))
+		}
+
+		attr := NewAttribute().
+			WithMetadata(args...).
+			withCaller(caller)
+		if e.level <= slog.LevelDebug {
+			attr.WithOutInjectingAttrsToSpan()
+		}
+
+		attrs := append(attr.Get(ctx), slog.String(config.EVENT_NAME, e.name))
+		l.LogAttrs(ctx, e.level, e.name, attrs...)
+	}
+
+	if e.recordOnSpan {
+		s := span.GetSpanFromContext(ctx)
+		if s.IsRecording() {
+			attrs := make([]attribute.KeyValue, 0, len(fields))
+			for _, f := range fields {
+				attrs = append(attrs, spanAttribute(f.key, f.value))
+			}
+			s.AddEvent(e.name, oteltrace.WithAttributes(attrs...))
+		}
+	}
+
+	if e.count {
+		getEventCounter().Add(ctx, 1, metric.WithAttributes(attribute.String(config.EVENT_NAME, e.name)))
+	}
+
+	return nil
+}
+
+// eventField is a serialisable field of an event
+type eventField struct {
+	key   string
+	value reflect.Value
+}
+
+// eventFields returns the serialisable fields of the event data, in the order of declaration.
+//
+// It returns an error if the data is not a struct or a required field has the zero value.
+func eventFields(data interface{}) ([]eventField, error) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, ErrInvalidEvent
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, ErrInvalidEvent
+	}
+
+	t := v.Type()
+	fields := make([]eventField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get(eventTagName)
+		if tag == "-" {
+			continue
+		}
+
+		key, options, _ := strings.Cut(tag, ",")
+		if key == "" {
+			key = field.Name
+		}
+
+		value := v.Field(i)
+		required := hasTagOption(options, "required")
+		omitEmpty := hasTagOption(options, "omitempty")
+
+		if value.IsZero() {
+			if required {
+				return nil, fmt.Errorf("%w: %s", ErrMissingEventField, key)
+			}
+			if omitEmpty {
+				continue
+			}
+		}
+
+		fields = append(fields, eventField{key: key, value: value})
+	}
+
+	return fields, nil
+}
+
+// hasTagOption returns true if the comma separated options contain the given option.
+func hasTagOption(options string, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// spanAttribute converts an event field to a span attribute.
+//
+// Primitive values keep their type, while any other value is converted to its JSON representation.
+func spanAttribute(key string, value reflect.Value) attribute.KeyValue {
+	switch value.Kind() {
+	case reflect.String:
+		return attribute.String(key, value.String())
+	case reflect.Bool:
+		return attribute.Bool(key, value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return attribute.Int64(key, value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return attribute.Int64(key, int64(value.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return attribute.Float64(key, value.Float())
+	default:
+		data, err := json.Marshal(value.Interface())
+		if err != nil {
+			return attribute.String(key, fmt.Sprintf("%v", value.Interface()))
+		}
+		return attribute.String(key, string(data))
+	}
+}
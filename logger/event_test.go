@@ -0,0 +1,144 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type bookingCreated struct {
+	BookingID string  `log:"booking_id,required"`
+	Amount    float64 `log:"amount,omitempty"`
+	Currency  string
+	Internal  string `log:"-"`
+	private   string
+}
+
+func TestEventLog(t *testing.T) {
+	t.Run("Logs the fields in the metadata", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		err := NewEvent("booking.created", bookingCreated{BookingID: "B1", Amount: 10.5, Currency: "EUR", Internal: "secret", private: "x"}).
+			Log(context.Background())
+		require.NoError(t, err)
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "INFO", record["level"])
+		assert.Equal(t, "booking.created", record["msg"])
+		assert.Equal(t, "booking.created", record[config.EVENT_NAME])
+		assert.Contains(t, record[config.FILE_PATH], "event_test.go")
+		assert.Equal(t, map[string]interface{}{"booking_id": "B1", "amount": 10.5, "Currency": "EUR"}, record[config.LOG_METADATA_KEY])
+	})
+
+	t.Run("Skips empty omitempty fields", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		err := NewEvent("booking.created", &bookingCreated{BookingID: "B1"}).
+			WithLevel(slog.LevelWarn).
+			Log(context.Background())
+		require.NoError(t, err)
+
+		record := decodeLog(t, output)
+		assert.Equal(t, "WARN", record["level"])
+		assert.Equal(t, map[string]interface{}{"booking_id": "B1", "Currency": ""}, record[config.LOG_METADATA_KEY])
+	})
+
+	t.Run("Fails on missing required fields", func(t *testing.T) {
+		output := captureLogs(t, slog.LevelDebug)
+
+		err := NewEvent("booking.created", bookingCreated{Amount: 10}).Log(context.Background())
+
+		require.ErrorIs(t, err, ErrMissingEventField)
+		assert.Contains(t, err.Error(), "booking_id")
+		assert.Empty(t, output.String())
+	})
+
+	t.Run("Fails on data that is not a struct", func(t *testing.T) {
+		err := NewEvent("booking.created", "not a struct").Log(context.Background())
+		require.ErrorIs(t, err, ErrInvalidEvent)
+
+		var nilEvent *bookingCreated
+		err = NewEvent("booking.created", nilEvent).Log(context.Background())
+		require.ErrorIs(t, err, ErrInvalidEvent)
+	})
+}
+
+func TestEventSpanAndCounter(t *testing.T) {
+	captureLogs(t, slog.LevelDebug)
+	ctx := context.Background()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	//nolint:errcheck
+	defer tp.Shutdown(ctx)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(mp)
+	eventCounterOnce = sync.Once{}
+	t.Cleanup(func() {
+		otel.SetMeterProvider(previous)
+		eventCounterOnce = sync.Once{}
+	})
+
+	spanCtx, span := tp.Tracer("test-tracer").Start(ctx, "test-span")
+	err := NewEvent("booking.created", bookingCreated{BookingID: "B1", Amount: 10.5}).
+		WithSpanEvent().
+		WithCounter().
+		Log(spanCtx)
+	require.NoError(t, err)
+	span.End()
+
+	ended := sr.Ended()
+	require.Len(t, ended, 1)
+	require.Len(t, ended[0].Events(), 1)
+	event := ended[0].Events()[0]
+	assert.Equal(t, "booking.created", event.Name)
+	assert.Contains(t, event.Attributes, attribute.String("booking_id", "B1"))
+	assert.Contains(t, event.Attributes, attribute.Float64("amount", 10.5))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+	name, _ := sum.DataPoints[0].Attributes.Value(config.EVENT_NAME)
+	assert.Equal(t, "booking.created", name.AsString())
+}
@@ -24,34 +24,104 @@ package logger // import "github.com/FLYR-Open-Source/flyr-lib-go/logger"
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
 	internalLogger "github.com/FLYR-Open-Source/flyr-lib-go/internal/logger"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/option"
 	slogmulti "github.com/samber/slog-multi"
 
 	"log/slog"
 )
 
+// levels are the global and per-package minimum log levels.
+//
+// They are initialized by the logger.InitLogger() function, and can be changed with logger.SetLevels(...).
+var levels = internalLogger.NewLevels("info", nil)
+
+// recordSettings are the settings applied to the attributes of the log records.
+type recordSettings struct {
+	// limits are the size limits applied to the log records.
+	limits internalLogger.Limits
+	// flatMetadata defines whether the metadata is emitted at the top level of the log record.
+	flatMetadata bool
+	// collisionPolicy defines how flat metadata keys that clash with reserved keys are handled.
+	collisionPolicy internalLogger.CollisionPolicy
+	// errorReporting defines whether error logs are formatted as Google Cloud Error Reporting events.
+	errorReporting bool
+	// serviceContext identifies the service in the Google Cloud Error Reporting events.
+	serviceContext internalLogger.ServiceContext
+}
+
+// settings are the record settings of the logger, read on every log call.
+//
+// They are initialized by the logger.InitLogger() function, and replaced as a whole so that
+// the logger can be initialized again while other goroutines log.
+var settings atomic.Pointer[recordSettings]
+
+// currentSettings returns the record settings of the logger, or the defaults if it is not initialized.
+func currentSettings() *recordSettings {
+	if s := settings.Load(); s != nil {
+		return s
+	}
+	return &recordSettings{collisionPolicy: internalLogger.CollisionPrefix}
+}
+
+// TruncationCount returns the number of log records that have been truncated
+// because they exceeded the configured size limits.
+func TruncationCount() uint64 {
+	return internalLogger.TruncationCount()
+}
+
+// CollisionCount returns the number of flat metadata keys that have clashed
+// with a reserved key (e.g. service.name, trace_id or code.*).
+func CollisionCount() uint64 {
+	return internalLogger.CollisionCount()
+}
+
 // InitLogger initializes the logger with the given configuration.
 //
 // The logger is then selected as the default logger for the application.
-func InitLogger() {
+// The service name and the resource are read from the environment, unless a configuration is given with WithConfig.
+func InitLogger(opts ...Option) {
 	cfg := config.NewLoggerConfig()
-	loggerLevel := internalLogger.ParseLogLevel(cfg.LogLevel())
+	if monitoringCfg, ok := option.ExplicitConfig(opts); ok {
+		cfg.Monitoring = monitoringCfg
+	}
+	levels.Set(cfg.LogLevel(), cfg.PackageLevels())
+	s := &recordSettings{
+		limits:          internalLogger.NewLimits(cfg),
+		flatMetadata:    cfg.FlatMetadata(),
+		collisionPolicy: internalLogger.ParseCollisionPolicy(cfg.MetadataCollisionPolicy()),
+		errorReporting:  cfg.ErrorReporting(),
+	}
+	if s.errorReporting {
+		s.serviceContext = internalLogger.NewServiceContext(cfg)
+	}
+	settings.Store(s)
 
-	jsonHanlder := internalLogger.NewJSONLogHandler(loggerLevel)
-	tracingHanlder := internalLogger.NewTracingHandler(loggerLevel)
+	jsonHanlder := internalLogger.NewJSONLogHandler(levels, s.limits)
+	levelHandler := internalLogger.NewLevelHandler(levels)
+	tracingHanlder := internalLogger.NewTracingHandler(levels)
 	sink := internalLogger.InjectRootAttrs(jsonHanlder, cfg)
 
 	l := slog.New(
 		slogmulti.
-			Pipe(tracingHanlder).
+			Pipe(levelHandler, tracingHanlder).
 			Handler(sink),
 	)
 
 	slog.SetDefault(l)
 }
 
+// SetLevels changes the minimum log level and the per-package levels of the logger, without restarting it.
+//
+// The per-package levels are given as package path to level (e.g. "github.com/acme/service/db": "debug"),
+// and also apply to the sub-packages. The accepted levels are debug, info, warn and error.
+func SetLevels(level string, packageLevels map[string]string) {
+	levels.Set(level, packageLevels)
+}
+
 // Debug logs a message at the debug level.
 //
 // Any attributes passed as arguments are added to the log message in the group "metadata",
@@ -95,6 +165,8 @@ func Warn(ctx context.Context, message string, args ...interface{}) {
 // and in the span that is retrieved from the given context.
 // Furthermore, if an error is passed as an argument, it is added to the log message in the attribute "error",
 // and also sets the span as errored (if the a span cna be retrieved from the given context).
+// If the Error Reporting format is enabled, the log also contains the stack trace, the service context
+// and the report location expected by Google Cloud Error Reporting.
 func Error(ctx context.Context, message string, err error, args ...interface{}) {
 	l := slog.Default()
 	attrs := NewAttribute().
@@ -0,0 +1,53 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package logger
+
+import (
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitLogger(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "env-service")
+	t.Setenv("LOG_ERROR_REPORTING", "true")
+	t.Cleanup(func() { settings.Store(nil) })
+
+	t.Run("with the environment", func(t *testing.T) {
+		InitLogger()
+
+		assert.Equal(t, "env-service", currentSettings().serviceContext.Service)
+	})
+
+	t.Run("with an explicit config", func(t *testing.T) {
+		cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+			"OTEL_SERVICE_NAME": "explicit-service",
+		}))
+		require.NoError(t, err)
+
+		InitLogger(WithConfig(cfg))
+
+		assert.Equal(t, "explicit-service", currentSettings().serviceContext.Service)
+	})
+}
@@ -30,18 +30,17 @@ import (
 
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/span"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
 )
 
 // setErroredSpan sets the span as errored if it is recording.
 //
-// It marks the current span (if found) as errored and records the given error.
+// It records the given error in the current span (if found), which is marked as errored
+// if the error classifier of the tracer classifies the error as a failure.
 func setErroredSpan(ctx context.Context, err error) {
 	span := span.GetSpanFromContext(ctx)
 
 	if span.IsRecording() {
-		span.SetStatus(codes.Error, err.Error())
-		span.RecordError(err)
+		span.SetError(err)
 	}
 }
 
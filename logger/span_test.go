@@ -23,7 +23,9 @@
 package logger
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -31,8 +33,55 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/span"
 )
 
+func TestSetErroredSpan(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus codes.Code
+	}{
+		{
+			name:           "with error",
+			err:            errors.New("test error"),
+			expectedStatus: codes.Error,
+		},
+		{
+			name:           "with canceled context",
+			err:            context.Canceled,
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:           "with error classified as not a failure",
+			err:            span.NewClassifiedError(errors.New("not found"), span.Classification{Code: "NOT_FOUND"}),
+			expectedStatus: codes.Unset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			ctx, s := tp.Tracer("test").Start(context.Background(), "test-span")
+
+			setErroredSpan(ctx, tt.err)
+			s.End()
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.expectedStatus, spans[0].Status().Code)
+			// the error is always recorded as an event
+			require.Len(t, spans[0].Events(), 1)
+			assert.Equal(t, "exception", spans[0].Events()[0].Name)
+		})
+	}
+}
+
 func TestValueToJSONString(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,83 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/config"
+
+import (
+	"time"
+
+	internalConfig "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
+)
+
+// Monitoring is the configuration of the tracer, the meter, the HTTP client and the middlewares.
+type Monitoring = internalConfig.Monitoring
+
+// ExporterSettings are the OTLP exporter settings of the Monitoring configuration.
+type ExporterSettings = internalConfig.ExporterSettings
+
+// Option configures how the Monitoring configuration is read.
+type Option = internalConfig.Option
+
+// New returns a new Monitoring configuration read from the environment variables
+// (and the configuration file in `FLYR_CONFIG_FILE`, if any).
+//
+// Every call reads the environment again, so differently configured instances can
+// coexist in the same process (e.g. in parallel tests).
+//
+// It returns an error if any of the environment variables cannot be parsed.
+func New(opts ...Option) (Monitoring, error) {
+	return internalConfig.ParseMonitoringConfig(opts...)
+}
+
+// WithEnvironment reads the configuration from the given variables, instead of the
+// environment of the process.
+func WithEnvironment(environ map[string]string) Option {
+	return internalConfig.WithEnvironment(environ)
+}
+
+// HeaderProvider provides the headers which are sent with every export request of the OTLP exporters,
+// and can refresh them while the exporters are running (e.g. short-lived tokens).
+type HeaderProvider = credentials.HeaderProvider
+
+// HeaderProviderFunc is a function that implements the HeaderProvider interface.
+type HeaderProviderFunc = credentials.HeaderProviderFunc
+
+// WithHeaderProvider sets the provider of the headers of the OTLP exporters.
+// It takes precedence over the headers files (`OTEL_EXPORTER_OTLP_HEADERS_FILE`).
+func WithHeaderProvider(provider HeaderProvider) Option {
+	return internalConfig.WithHeaderProvider(provider)
+}
+
+// NewRefreshingHeaderProvider returns a HeaderProvider that calls fetch at most once per refresh interval,
+// and caches its headers in between. If fetch fails after the headers were loaded once,
+// the last headers are kept until the next refresh.
+func NewRefreshingHeaderProvider(fetch HeaderProviderFunc, interval time.Duration) HeaderProvider {
+	return credentials.NewRefreshingHeaderProvider(fetch, interval)
+}
+
+// NewFileHeaderProvider returns a HeaderProvider that reads the headers from a file (e.g. a mounted Kubernetes secret),
+// in the format of `OTEL_EXPORTER_OTLP_HEADERS` (one pair per line is also accepted), and reads it again
+// once the refresh interval has elapsed.
+func NewFileHeaderProvider(path string, interval time.Duration) HeaderProvider {
+	return credentials.NewFileHeaderProvider(path, interval)
+}
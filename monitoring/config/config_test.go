@@ -0,0 +1,60 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	first, err := New(WithEnvironment(map[string]string{
+		"OTEL_SERVICE_NAME":             "first",
+		"OTEL_METRICS_INTERVAL_SECONDS": "5",
+	}))
+	require.NoError(t, err)
+
+	second, err := New(WithEnvironment(map[string]string{
+		"OTEL_SERVICE_NAME": "second",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "first", first.Service())
+	assert.Equal(t, 5*time.Second, first.MetricsInterval())
+	assert.Equal(t, "second", second.Service())
+	assert.Equal(t, 60*time.Second, second.MetricsInterval())
+}
+
+func TestNewInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(WithEnvironment(map[string]string{
+		"OTEL_METRICS_INTERVAL_SECONDS": "often",
+	}))
+	require.Error(t, err)
+}
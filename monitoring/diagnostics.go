@@ -0,0 +1,256 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package monitoring // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring"
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/diagnostics"
+)
+
+const (
+	// redacted replaces the secret values in the report.
+	redacted = "REDACTED"
+	// notStarted is the exporter of a signal which has not been started.
+	notStarted = "not started"
+)
+
+// Report is the effective configuration of the telemetry, together with the state of the exporters.
+type Report struct {
+	Service     string           `json:"service"`
+	SDKDisabled bool             `json:"sdk_disabled"`
+	ConfigFile  string           `json:"config_file,omitempty"`
+	Logger      LoggerReport     `json:"logger"`
+	Traces      SignalReport     `json:"traces"`
+	Metrics     SignalReport     `json:"metrics"`
+	Middleware  MiddlewareReport `json:"middleware"`
+}
+
+// LoggerReport is the effective configuration of the logger.
+type LoggerReport struct {
+	Level             string `json:"level"`
+	MaxValueLength    int    `json:"max_value_length"`
+	MaxMetadataKeys   int    `json:"max_metadata_keys"`
+	MaxDepth          int    `json:"max_depth"`
+	MaxRecordSize     int    `json:"max_record_size"`
+	FlatMetadata      bool   `json:"flat_metadata"`
+	MetadataCollision string `json:"metadata_collision"`
+	ErrorReporting    bool   `json:"error_reporting"`
+	ResourceAttrs     bool   `json:"resource_attributes"`
+}
+
+// SignalReport is the effective configuration and the state of the exporter of a signal.
+//
+// The header values, and the password and the query values of the endpoint are redacted.
+type SignalReport struct {
+	Exporter    string            `json:"exporter"`
+	Protocol    string            `json:"protocol,omitempty"`
+	Endpoint    string            `json:"endpoint,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	HeadersFile string            `json:"headers_file,omitempty"`
+	Timeout     string            `json:"timeout,omitempty"`
+	Compression string            `json:"compression,omitempty"`
+	Insecure    bool              `json:"insecure"`
+	TLS         bool              `json:"tls"`
+	Interval    string            `json:"interval,omitempty"`
+	ConfigError string            `json:"config_error,omitempty"`
+	Resource    map[string]string `json:"resource,omitempty"`
+	LastSuccess *time.Time        `json:"last_success,omitempty"`
+	LastFailure *time.Time        `json:"last_failure,omitempty"`
+	LastError   string            `json:"last_error,omitempty"`
+}
+
+// MiddlewareReport is the effective configuration of the middlewares.
+type MiddlewareReport struct {
+	ExcludedPaths []string `json:"excluded_paths,omitempty"`
+}
+
+// Diagnostics returns the effective configuration of the logger, the tracer, the meter and the middlewares,
+// the resource attributes and the result of the last export of each signal.
+//
+// Once the telemetry is started with Start, the configuration which Start used is reported.
+// Otherwise the configuration is read from the environment, and an error is returned if it is invalid.
+//
+// Secrets, like the exporter headers, are redacted.
+func Diagnostics() (Report, error) {
+	if s := started.Load(); s != nil {
+		return newReport(s.logger, s.monitoring), nil
+	}
+
+	loggerCfg, err := config.ParseLoggerConfig()
+	if err != nil {
+		return Report{}, err
+	}
+
+	monitoringCfg, err := config.ParseMonitoringConfig()
+	if err != nil {
+		return Report{}, err
+	}
+
+	return newReport(loggerCfg, monitoringCfg), nil
+}
+
+// newReport builds the report for the given configurations.
+func newReport(loggerCfg config.Logger, monitoringCfg config.Monitoring) Report {
+	traces, tracesErr := monitoringCfg.TracesExporter()
+	metrics, metricsErr := monitoringCfg.MetricsExporter()
+
+	report := Report{
+		Service:     monitoringCfg.Service(),
+		SDKDisabled: monitoringCfg.SDKDisabled(),
+		ConfigFile:  os.Getenv(config.ConfigFileEnv),
+		Logger: LoggerReport{
+			Level:             loggerCfg.LogLevel(),
+			MaxValueLength:    loggerCfg.MaxValueLength(),
+			MaxMetadataKeys:   loggerCfg.MaxMetadataKeys(),
+			MaxDepth:          loggerCfg.MaxDepth(),
+			MaxRecordSize:     loggerCfg.MaxRecordSize(),
+			FlatMetadata:      loggerCfg.FlatMetadata(),
+			MetadataCollision: loggerCfg.MetadataCollisionPolicy(),
+			ErrorReporting:    loggerCfg.ErrorReporting(),
+			ResourceAttrs:     loggerCfg.ResourceAttributes(),
+		},
+		Traces:  newSignalReport(diagnostics.SignalTraces, monitoringCfg.ExporterTracesProtocol(), traces, tracesErr),
+		Metrics: newSignalReport(diagnostics.SignalMetrics, monitoringCfg.ExporterMetricsProtocol(), metrics, metricsErr),
+		Middleware: MiddlewareReport{
+			ExcludedPaths: monitoringCfg.MiddlewareExcludedPaths(),
+		},
+	}
+	report.Metrics.Interval = monitoringCfg.MetricsInterval().String()
+
+	return report
+}
+
+// newSignalReport builds the report of a signal from its exporter configuration and state.
+func newSignalReport(signal diagnostics.Signal, protocol string, exporter config.OTLPExporter, err error) SignalReport {
+	report := SignalReport{
+		Exporter:    notStarted,
+		Protocol:    protocol,
+		Endpoint:    redactEndpoint(exporter.Endpoint),
+		HeadersFile: exporter.HeadersFile,
+		Compression: exporter.Compression,
+		Insecure:    exporter.Insecure,
+		TLS:         exporter.TLS != nil,
+	}
+	if exporter.Timeout > 0 {
+		report.Timeout = exporter.Timeout.String()
+	}
+	if len(exporter.Headers) > 0 {
+		report.Headers = make(map[string]string, len(exporter.Headers))
+		for k := range exporter.Headers {
+			report.Headers[k] = redacted
+		}
+	}
+	if err != nil {
+		report.ConfigError = err.Error()
+	}
+
+	state, ok := diagnostics.Get(signal)
+	if !ok {
+		return report
+	}
+
+	report.Exporter = state.Exporter
+	report.Resource = state.Resource
+	report.LastError = state.LastError
+	if !state.LastSuccess.IsZero() {
+		report.LastSuccess = &state.LastSuccess
+	}
+	if !state.LastFailure.IsZero() {
+		report.LastFailure = &state.LastFailure
+	}
+
+	return report
+}
+
+// redactEndpoint hides the password and the query values of the endpoint, if any.
+func redactEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			query[key] = []string{redacted}
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+// String returns a one-line summary of the report.
+func (r Report) String() string {
+	parts := []string{
+		"service=" + r.Service,
+		"log_level=" + r.Logger.Level,
+		"traces=" + r.Traces.summary(),
+		"metrics=" + r.Metrics.summary(),
+		"metrics_interval=" + r.Metrics.Interval,
+	}
+	if r.ConfigFile != "" {
+		parts = append(parts, "config_file="+r.ConfigFile)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// summary returns the exporter and its endpoint, if any.
+func (s SignalReport) summary() string {
+	if s.Endpoint == "" {
+		return s.Exporter
+	}
+	return fmt.Sprintf("%s(%s)", s.Exporter, s.Endpoint)
+}
+
+// DiagnosticsHandler returns an http.Handler that responds with the Diagnostics report as JSON.
+//
+// The report does not contain secrets, but it describes the infrastructure of the service,
+// so the handler should not be exposed publicly.
+func DiagnosticsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report, err := Diagnostics()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
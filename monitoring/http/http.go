@@ -27,7 +27,7 @@ import (
 	"net/http"
 	"net/http/httptrace"
 
-	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/option"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
@@ -39,18 +39,20 @@ import (
 // tracing of outgoing HTTP requests made by the client, enabling better observability
 // and monitoring of requests in a distributed system.
 //
+// The configuration is read from the environment, unless it is given with WithConfig.
+//
 // Returns the configured http.Client with the OpenTelemetry transport set.
-func SetHttpTransport(client http.Client) http.Client {
-	opts := make([]otelhttp.Option, 0)
+func SetHttpTransport(client http.Client, opts ...Option) http.Client {
+	otelOpts := make([]otelhttp.Option, 0)
 
-	cfg := config.NewMonitoringConfig()
+	cfg := option.MonitoringConfig(opts)
 	if cfg.EnableHttpClientTraces() {
-		opts = append(opts, otelhttp.WithClientTrace(func(ctx context.Context) *httptrace.ClientTrace {
+		otelOpts = append(otelOpts, otelhttp.WithClientTrace(func(ctx context.Context) *httptrace.ClientTrace {
 			return otelhttptrace.NewClientTrace(ctx)
 		}))
 	}
 
-	client.Transport = otelhttp.NewTransport(http.DefaultTransport, opts...)
+	client.Transport = otelhttp.NewTransport(http.DefaultTransport, otelOpts...)
 	return client
 }
 
@@ -61,8 +63,10 @@ func SetHttpTransport(client http.Client) http.Client {
 // outgoing HTTP requests made by the client, providing enhanced observability for
 // applications that rely on external HTTP communications.
 //
+// The configuration is read from the environment, unless it is given with WithConfig.
+//
 // Returns a new http.Client with OpenTelemetry tracing configured.
-func NewHttpClient() http.Client {
+func NewHttpClient(opts ...Option) http.Client {
 	client := http.Client{}
-	return SetHttpTransport(client)
+	return SetHttpTransport(client, opts...)
 }
@@ -52,3 +52,13 @@ func TestNewHttpClient(t *testing.T) {
 	client := NewHttpClient()
 	require.NotNil(t, client.Transport)
 }
+
+func TestNewHttpClient_WithConfig(t *testing.T) {
+	cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+		"OTEL_ENABLE_HTTP_CLIENT_TRACES": "true",
+	}))
+	require.NoError(t, err)
+
+	client := NewHttpClient(WithConfig(cfg))
+	require.NotNil(t, client.Transport)
+}
@@ -24,8 +24,9 @@ package meter // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/mete
 
 import (
 	"context"
+	"sync"
 
-	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/option"
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/version"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
@@ -37,6 +38,12 @@ import (
 // The default meter is initialized by the meter.StartDefaultMeter(...) function.
 var defaultMeter metric.Meter
 
+var (
+	defaultProviderMu sync.Mutex
+	// defaultProvider is the meter provider of the default meter.
+	defaultProvider *meterProvider
+)
+
 // StartDefaultMeter initializes and starts the default OpenTelemetry Meter.
 //
 // This function checks if custom metrics are enabled in the provided configuration. If they are enabled,
@@ -46,20 +53,28 @@ var defaultMeter metric.Meter
 //
 // The function also sets the global default Meter to be used for custom metrics in the
 // application. If custom metrics are not enabled, it returns a noop Meter.
+// Its MeterProvider is set as the global meter provider for OpenTelemetry.
+//
+// The configuration is read from the environment, unless it is given with WithConfig.
 //
 // It returns the created Meter.
 //
 // For learning more about the Otel Metrics Data Model, please reference to https://opentelemetry.io/docs/specs/otel/metrics/data-model
-func StartDefaultMeter(ctx context.Context) (metric.Meter, error) {
-	cfg := config.NewMonitoringConfig()
+func StartDefaultMeter(ctx context.Context, opts ...Option) (metric.Meter, error) {
+	cfg := option.MonitoringConfig(opts)
 
-	err := initializeMeterProvider(ctx, cfg)
+	mp, err := initializeMeterProvider(ctx, cfg)
 	if err != nil {
+		otel.SetMeterProvider(noop.NewMeterProvider())
 		return noop.Meter{}, err
 	}
+	otel.SetMeterProvider(mp.MeterProvider)
+
+	defaultProviderMu.Lock()
+	defaultProvider = mp
+	defaultProviderMu.Unlock()
 
-	mt := otel.GetMeterProvider()
-	defaultMeter = mt.Meter(
+	defaultMeter = mp.Meter(
 		cfg.Service(),
 		metric.WithInstrumentationVersion(version.Version()),
 	)
@@ -87,4 +87,21 @@ func TestStartDefaultMeter(t *testing.T) {
 		assert.Equal(t, noop.Meter{}, meter)
 		assert.Nil(t, defaultMeter)
 	})
+	t.Run("with explicit config", func(t *testing.T) {
+		cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+			"OTEL_SERVICE_NAME":       "explicit-service",
+			"OTEL_EXPORTER_OTLP_TEST": "true",
+		}))
+		require.NoError(t, err)
+
+		// Reset the global defaultMeter
+		defaultMeter = nil
+
+		meter, err := StartDefaultMeter(ctx, WithConfig(cfg))
+
+		require.NoError(t, err)
+		assert.NotNil(t, meter)
+		assert.Equal(t, defaultMeter, meter)
+		require.NoError(t, ShutdownMeterProvider(ctx))
+	})
 }
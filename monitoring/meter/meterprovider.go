@@ -25,17 +25,24 @@ package meter // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/mete
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	internalCredentials "github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/diagnostics"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/fileexporter"
+	internalResource "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
 	"go.opentelemetry.io/otel"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // ErrMetricsProviderNotInitialized is returned when the metrics provider is not initialized
@@ -47,6 +54,10 @@ var ErrExporterProtocolNotSupported = errors.New("exporter protocol not supporte
 // getExporter returns an OTLP exporter based on the exporter protocol.
 // If the exporter protocol is not supported, it returns nil.
 //
+// The endpoint, headers, timeout, compression and TLS settings are read from the
+// `OTEL_EXPORTER_OTLP_*` environment variables and passed explicitly to the exporter.
+// An invalid combination of these settings is returned as an error.
+//
 // Valid values are:
 //
 // - grpc to use OTLP/gRPC
@@ -58,60 +69,183 @@ func getExporter(ctx context.Context, cfg config.MonitoringConfig) (sdkmetric.Ex
 		return stdoutmetric.New(stdoutmetric.WithPrettyPrint())
 	}
 
+	exporterCfg, err := cfg.MetricsExporter()
+	if err != nil {
+		return nil, err
+	}
+
 	switch cfg.ExporterMetricsProtocol() {
 	case "grpc":
-		return otlpmetricgrpc.New(ctx)
+		return otlpmetricgrpc.New(ctx, grpcOptions(exporterCfg)...)
 	case "http/protobuf":
-		return otlpmetrichttp.New(ctx)
+		return otlpmetrichttp.New(ctx, httpOptions(exporterCfg)...)
 	default:
 		return nil, ErrExporterProtocolNotSupported
 	}
 }
 
+// getExporters returns the exporters of the types given in `OTEL_METRICS_EXPORTER`.
+//
+// If the exporters are disabled (with `OTEL_SDK_DISABLED` or `OTEL_METRICS_EXPORTER=none`), it returns no exporters.
+func getExporters(ctx context.Context, cfg config.MonitoringConfig) ([]sdkmetric.Exporter, error) {
+	types, err := cfg.MetricsExporterTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	exporters := make([]sdkmetric.Exporter, 0, len(types))
+	for _, t := range types {
+		var exporter sdkmetric.Exporter
+
+		switch t {
+		case config.ExporterNone:
+			return nil, nil
+		case config.ExporterConsole:
+			exporter, err = stdoutmetric.New(stdoutmetric.WithPrettyPrint())
+		case config.ExporterFile:
+			exporter, err = getFileExporter(ctx, cfg)
+		default:
+			exporter, err = getExporter(ctx, cfg)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		exporters = append(exporters, exporter)
+	}
+
+	return exporters, nil
+}
+
+// getFileExporter returns an exporter which writes the metrics as OTLP JSON lines to the file given in `OTEL_EXPORTER_FILE_METRICS_PATH`.
+func getFileExporter(ctx context.Context, cfg config.MonitoringConfig) (sdkmetric.Exporter, error) {
+	fileCfg, err := cfg.MetricsFileExporter()
+	if err != nil {
+		return nil, err
+	}
+
+	return fileexporter.NewMetricExporter(ctx, fileCfg)
+}
+
+// grpcOptions converts the exporter configuration to OTLP/gRPC exporter options.
+func grpcOptions(cfg config.OTLPExporter) []otlpmetricgrpc.Option {
+	var opts []otlpmetricgrpc.Option
+
+	if strings.Contains(cfg.Endpoint, "://") {
+		opts = append(opts, otlpmetricgrpc.WithEndpointURL(cfg.Endpoint))
+	} else if cfg.Endpoint != "" {
+		opts = append(opts, otlpmetricgrpc.WithEndpoint(cfg.Endpoint))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(cfg.Headers))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlpmetricgrpc.WithTimeout(cfg.Timeout))
+	}
+	if cfg.Compression != "" {
+		opts = append(opts, otlpmetricgrpc.WithCompressor(cfg.Compression))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	} else if cfg.TLS != nil {
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(cfg.TLS)))
+	}
+	if cfg.HeaderProvider != nil {
+		perRPC := internalCredentials.NewPerRPCCredentials(cfg.HeaderProvider, !cfg.Insecure)
+		opts = append(opts, otlpmetricgrpc.WithDialOption(grpc.WithPerRPCCredentials(perRPC)))
+	}
+
+	return opts
+}
+
+// httpOptions converts the exporter configuration to OTLP/HTTP exporter options.
+func httpOptions(cfg config.OTLPExporter) []otlpmetrichttp.Option {
+	var opts []otlpmetrichttp.Option
+
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlpmetrichttp.WithEndpointURL(cfg.Endpoint))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlpmetrichttp.WithHeaders(cfg.Headers))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlpmetrichttp.WithTimeout(cfg.Timeout))
+	}
+	if cfg.Compression == "gzip" {
+		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlpmetrichttp.WithInsecure())
+	} else if cfg.TLS != nil {
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(cfg.TLS))
+	}
+	if cfg.HeaderProvider != nil {
+		// the client replaces the TLS configuration and the timeout of the exporter
+		opts = append(opts, otlpmetrichttp.WithHTTPClient(internalCredentials.NewHTTPClient(cfg.HeaderProvider, cfg.TLS, cfg.Timeout)))
+	}
+
+	return opts
+}
+
+// meterProvider is a MeterProvider with the readers of which the interval can be changed while it is in use.
+type meterProvider struct {
+	metric.MeterProvider
+
+	// readers are the readers of the exporters (see SetInterval)
+	readers []*intervalReader
+}
+
 // initializeMeterProvider creates and configures a new OpenTelemetry MeterProvider.
 //
 // This function initializes a MeterProvider with the specified configuration,
 // including a resource that describes the service, version, environment, and tenant.
-// This MeterProvider is also set as the global meter provider for OpenTelemetry.
+// A noop MeterProvider is returned if the service name is not set or the metrics are not exported.
 //
 // It returns an error if any occurred.
-func initializeMeterProvider(ctx context.Context, cfg config.Monitoring) error {
+func initializeMeterProvider(ctx context.Context, cfg config.MonitoringConfig) (*meterProvider, error) {
 	if cfg.Service() == "" {
-		otel.SetMeterProvider(noop.NewMeterProvider())
-		return nil
+		diagnostics.SetExporter(diagnostics.SignalMetrics, config.ExporterNone, nil)
+		return &meterProvider{MeterProvider: noop.NewMeterProvider()}, nil
 	}
 
-	exporter, err := getExporter(ctx, cfg)
+	exporters, err := getExporters(ctx, cfg)
 	if err != nil {
-		otel.SetMeterProvider(noop.NewMeterProvider())
-		return err
+		return nil, err
+	}
+
+	if len(exporters) == 0 {
+		diagnostics.SetExporter(diagnostics.SignalMetrics, config.ExporterNone, nil)
+		return &meterProvider{MeterProvider: noop.NewMeterProvider()}, nil
 	}
 
-	resourceInfo, err := resource.New(
+	resourceInfo, err := internalResource.WithAttributes(
 		ctx,
-		resource.WithFromEnv(),
-		resource.WithTelemetrySDK(),
-		resource.WithContainer(),
-		resource.WithHost(),
-		resource.WithAttributes(
-			attribute.String(config.EXPORTER_PROTOCOL, cfg.ExporterMetricsProtocol()),
-		),
+		cfg.Service(),
+		attribute.String(config.EXPORTER_PROTOCOL, cfg.ExporterMetricsProtocol()),
 	)
 	if err != nil {
-		otel.SetMeterProvider(noop.NewMeterProvider())
-		return err
+		return nil, err
 	}
 
 	interval := cfg.MetricsInterval()
 
-	mt := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(interval))),
-		sdkmetric.WithResource(resourceInfo),
-	)
+	types, _ := cfg.MetricsExporterTypes()
+	diagnostics.SetExporter(diagnostics.SignalMetrics, diagnostics.ExporterName(types, cfg.ExporterMetricsProtocol()), resourceInfo)
 
-	otel.SetMeterProvider(mt)
+	opts := []sdkmetric.Option{
+		sdkmetric.WithResource(resourceInfo),
+	}
+	readers := make([]*intervalReader, 0, len(exporters))
+	for _, exporter := range exporters {
+		reader := newIntervalReader(diagnostics.WrapMetricExporter(exporter), interval)
+		readers = append(readers, reader)
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
 
-	return nil
+	return &meterProvider{
+		MeterProvider: sdkmetric.NewMeterProvider(opts...),
+		readers:       readers,
+	}, nil
 }
 
 // ShutdownMeterProvider gracefully shuts down the global MeterProvider.
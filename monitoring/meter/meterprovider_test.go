@@ -29,7 +29,9 @@ import (
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
 func TestGetExporter(t *testing.T) {
@@ -69,6 +71,32 @@ func TestGetExporter(t *testing.T) {
 		require.Nil(t, client)
 		require.ErrorIs(t, err, ErrExporterProtocolNotSupported)
 	})
+	t.Run("ReturnsExporterWithSettings", func(t *testing.T) {
+		cfg.ExporterProtocolCfg = "http/protobuf"
+		cfg.TestExporterCfg = false
+		cfg.ExporterCfg = config.ExporterSettings{
+			EndpointCfg:    "http://collector:4318",
+			HeadersCfg:     "api-key=secret",
+			TimeoutCfg:     "5000",
+			CompressionCfg: "gzip",
+		}
+		defer func() { cfg.ExporterCfg = config.ExporterSettings{} }()
+
+		client, err := getExporter(ctx, cfg)
+		require.NotNil(t, client)
+		require.NoError(t, err)
+	})
+
+	t.Run("ReturnsErrorForInvalidSettings", func(t *testing.T) {
+		cfg.ExporterProtocolCfg = "grpc"
+		cfg.TestExporterCfg = false
+		cfg.ExporterCfg = config.ExporterSettings{CompressionCfg: "zstd"}
+		defer func() { cfg.ExporterCfg = config.ExporterSettings{} }()
+
+		client, err := getExporter(ctx, cfg)
+		require.Nil(t, client)
+		require.ErrorIs(t, err, config.ErrInvalidExporterConfig)
+	})
 }
 
 func TestNewMeterProvider(t *testing.T) {
@@ -78,23 +106,24 @@ func TestNewMeterProvider(t *testing.T) {
 	cfg.ExporterProtocolCfg = "grpc"
 
 	t.Run("ReturnsNoError", func(t *testing.T) {
-		err := initializeMeterProvider(ctx, cfg)
+		_, err := initializeMeterProvider(ctx, cfg)
 		require.NoError(t, err)
 	})
 
 	t.Run("SetsCorrectResourceAttributes", func(t *testing.T) {
-		err := initializeMeterProvider(ctx, cfg)
+		mp, err := initializeMeterProvider(ctx, cfg)
 		require.NoError(t, err)
+		require.Len(t, mp.readers, 1)
 
-		// Ensure tracerProvider is still usable
-		meter := otel.GetMeterProvider().Meter("test-meter")
+		// Ensure meterProvider is still usable
+		meter := mp.Meter("test-meter")
 		c, err := meter.Float64Counter("test-counter")
 		require.NoError(t, err)
 		c.Add(ctx, 1)
 	})
 
 	t.Run("ConfiguresTextMapPropagator", func(t *testing.T) {
-		err := initializeMeterProvider(ctx, cfg)
+		_, err := initializeMeterProvider(ctx, cfg)
 		require.NoError(t, err)
 
 		// Retrieve the global TextMapPropagator and confirm it’s a composite propagator.
@@ -119,3 +148,33 @@ func TestNewMeterProvider(t *testing.T) {
 		require.NotNil(t, baggageInjected, "Baggage should be part of the TextMapPropagator")
 	})
 }
+
+func TestNewMeterProvider_ExporterTypes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("keeps the metrics while the traces are disabled", func(t *testing.T) {
+		cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+			"OTEL_SERVICE_NAME":     "test-service",
+			"OTEL_TRACES_EXPORTER":  "none",
+			"OTEL_METRICS_EXPORTER": "console",
+		}))
+		require.NoError(t, err)
+
+		_, err = StartDefaultMeter(ctx, WithConfig(cfg))
+		require.NoError(t, err)
+		require.IsType(t, &sdkmetric.MeterProvider{}, otel.GetMeterProvider())
+		require.NoError(t, ShutdownMeterProvider(ctx))
+	})
+
+	t.Run("disables the metrics", func(t *testing.T) {
+		cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+			"OTEL_SERVICE_NAME": "test-service",
+			"OTEL_SDK_DISABLED": "true",
+		}))
+		require.NoError(t, err)
+
+		_, err = StartDefaultMeter(ctx, WithConfig(cfg))
+		require.NoError(t, err)
+		require.IsType(t, noop.MeterProvider{}, otel.GetMeterProvider())
+	})
+}
@@ -0,0 +1,182 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package meter // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/meter"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	// exportTimeout is the deadline of each periodic export.
+	exportTimeout = 30 * time.Second
+)
+
+// intervalReader collects and exports the metrics periodically, like the sdkmetric.PeriodicReader,
+// but its interval can be changed while it is running.
+//
+// It embeds a sdkmetric.ManualReader, which implements the sdkmetric.Reader interface,
+// and drives the collection with its own ticker. The exports, including the forced flushes,
+// all run in the same goroutine, so the exporter never exports concurrently.
+type intervalReader struct {
+	*sdkmetric.ManualReader
+
+	exporter sdkmetric.Exporter
+	// interval is the latest interval, which is picked up by the run loop once intervalChanged is signalled
+	interval        atomic.Int64
+	intervalChanged chan struct{}
+	flushes         chan flushRequest
+	done            chan struct{}
+	stopped         chan struct{}
+	once            sync.Once
+}
+
+// flushRequest asks the run loop to export the metrics immediately, and to send the result to reply.
+type flushRequest struct {
+	ctx   context.Context
+	reply chan error
+}
+
+// newIntervalReader returns an intervalReader which exports the metrics to the exporter at the given interval.
+func newIntervalReader(exporter sdkmetric.Exporter, interval time.Duration) *intervalReader {
+	r := &intervalReader{
+		ManualReader: sdkmetric.NewManualReader(
+			sdkmetric.WithTemporalitySelector(exporter.Temporality),
+			sdkmetric.WithAggregationSelector(exporter.Aggregation),
+		),
+		exporter:        exporter,
+		intervalChanged: make(chan struct{}, 1),
+		flushes:         make(chan flushRequest),
+		done:            make(chan struct{}),
+		stopped:         make(chan struct{}),
+	}
+
+	go r.run(interval)
+
+	return r
+}
+
+// run exports the metrics at every tick and on every flush request, until the reader is shut down.
+func (r *intervalReader) run(interval time.Duration) {
+	defer close(r.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+			_ = r.export(ctx)
+			cancel()
+		case <-r.intervalChanged:
+			ticker.Reset(time.Duration(r.interval.Load()))
+		case req := <-r.flushes:
+			req.reply <- errors.Join(r.export(req.ctx), r.exporter.ForceFlush(req.ctx))
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// export collects the metrics and exports them.
+func (r *intervalReader) export(ctx context.Context) error {
+	rm := metricdata.ResourceMetrics{}
+	if err := r.Collect(ctx, &rm); err != nil {
+		return err
+	}
+	return r.exporter.Export(ctx, &rm)
+}
+
+// setInterval changes the interval of the exports. It does not wait for an export in progress.
+func (r *intervalReader) setInterval(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	r.interval.Store(int64(interval))
+	select {
+	case r.intervalChanged <- struct{}{}:
+	default:
+		// the run loop has not picked up the previous change yet, and it will read the latest interval
+	}
+}
+
+// ForceFlush exports the metrics immediately, in the run loop.
+func (r *intervalReader) ForceFlush(ctx context.Context) error {
+	req := flushRequest{ctx: ctx, reply: make(chan error, 1)}
+	select {
+	case r.flushes <- req:
+	case <-r.done:
+		return sdkmetric.ErrReaderShutdown
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-req.reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown stops the periodic exports, exports the metrics a last time and shuts down the exporter.
+func (r *intervalReader) Shutdown(ctx context.Context) error {
+	err := sdkmetric.ErrReaderShutdown
+	r.once.Do(func() {
+		close(r.done)
+		<-r.stopped
+
+		err = errors.Join(
+			r.export(ctx),
+			r.ManualReader.Shutdown(ctx),
+			r.exporter.Shutdown(ctx),
+		)
+	})
+	return err
+}
+
+// SetInterval changes the interval at which the default meter provider exports the metrics,
+// without restarting it. A non-positive interval is ignored.
+//
+// It returns whether the interval was changed, which is not the case if the default meter does not export any metrics.
+func SetInterval(interval time.Duration) bool {
+	defaultProviderMu.Lock()
+	defer defaultProviderMu.Unlock()
+
+	if interval <= 0 || defaultProvider == nil || len(defaultProvider.readers) == 0 {
+		return false
+	}
+
+	for _, r := range defaultProvider.readers {
+		r.setInterval(interval)
+	}
+	return true
+}
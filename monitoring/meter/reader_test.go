@@ -0,0 +1,197 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package meter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// countingExporter counts the exports, the concurrent exports and the shutdowns.
+// If release is set, every export waits until it is closed.
+type countingExporter struct {
+	exports    atomic.Int64
+	exporting  atomic.Int64
+	concurrent atomic.Int64
+	shutdowns  atomic.Int64
+	release    chan struct{}
+}
+
+func (e *countingExporter) Temporality(k sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(k)
+}
+
+func (e *countingExporter) Aggregation(k sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(k)
+}
+
+func (e *countingExporter) Export(context.Context, *metricdata.ResourceMetrics) error {
+	if e.exporting.Add(1) > 1 {
+		e.concurrent.Add(1)
+	}
+	defer e.exporting.Add(-1)
+
+	if e.release != nil {
+		<-e.release
+	} else {
+		time.Sleep(time.Millisecond)
+	}
+	e.exports.Add(1)
+	return nil
+}
+
+func (e *countingExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *countingExporter) Shutdown(context.Context) error {
+	e.shutdowns.Add(1)
+	return nil
+}
+
+func TestIntervalReader(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("exports at the new interval", func(t *testing.T) {
+		exporter := &countingExporter{}
+		reader := newIntervalReader(exporter, time.Hour)
+		provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		counter, err := provider.Meter("test").Int64Counter("requests")
+		require.NoError(t, err)
+		counter.Add(ctx, 1)
+
+		assert.Zero(t, exporter.exports.Load())
+
+		reader.setInterval(10 * time.Millisecond)
+		assert.Eventually(t, func() bool {
+			return exporter.exports.Load() >= 2
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, provider.Shutdown(ctx))
+		assert.Equal(t, int64(1), exporter.shutdowns.Load())
+	})
+
+	t.Run("exports on flush and shutdown", func(t *testing.T) {
+		exporter := &countingExporter{}
+		reader := newIntervalReader(exporter, time.Hour)
+		provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		require.NoError(t, provider.ForceFlush(ctx))
+		assert.Equal(t, int64(1), exporter.exports.Load())
+
+		require.NoError(t, provider.Shutdown(ctx))
+		assert.Equal(t, int64(2), exporter.exports.Load())
+
+		// the interval of a reader which is shut down is ignored
+		reader.setInterval(time.Millisecond)
+		require.ErrorIs(t, reader.Shutdown(ctx), sdkmetric.ErrReaderShutdown)
+		require.ErrorIs(t, reader.ForceFlush(ctx), sdkmetric.ErrReaderShutdown)
+	})
+
+	t.Run("never exports concurrently", func(t *testing.T) {
+		exporter := &countingExporter{}
+		reader := newIntervalReader(exporter, time.Millisecond)
+		provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, provider.ForceFlush(ctx))
+			}()
+		}
+		wg.Wait()
+
+		require.NoError(t, provider.Shutdown(ctx))
+		assert.GreaterOrEqual(t, exporter.exports.Load(), int64(11))
+		assert.Zero(t, exporter.concurrent.Load())
+	})
+
+	t.Run("changes the interval during an export", func(t *testing.T) {
+		exporter := &countingExporter{release: make(chan struct{})}
+		reader := newIntervalReader(exporter, time.Millisecond)
+		provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		require.Eventually(t, func() bool {
+			return exporter.exporting.Load() == 1
+		}, 5*time.Second, time.Millisecond)
+
+		changed := make(chan struct{})
+		go func() {
+			reader.setInterval(time.Hour)
+			reader.setInterval(time.Minute)
+			close(changed)
+		}()
+		select {
+		case <-changed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the interval change waits for the export")
+		}
+
+		close(exporter.release)
+		require.NoError(t, provider.Shutdown(ctx))
+	})
+}
+
+func TestSetInterval(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("changes the interval of the default meter provider", func(t *testing.T) {
+		cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+			"OTEL_SERVICE_NAME":       "test-service",
+			"OTEL_EXPORTER_OTLP_TEST": "true",
+		}))
+		require.NoError(t, err)
+
+		_, err = StartDefaultMeter(ctx, WithConfig(cfg))
+		require.NoError(t, err)
+		defer func() { require.NoError(t, ShutdownMeterProvider(ctx)) }()
+
+		assert.True(t, SetInterval(time.Minute))
+		assert.False(t, SetInterval(0))
+	})
+
+	t.Run("without exporters", func(t *testing.T) {
+		cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+			"OTEL_SERVICE_NAME":     "test-service",
+			"OTEL_METRICS_EXPORTER": "none",
+		}))
+		require.NoError(t, err)
+
+		_, err = StartDefaultMeter(ctx, WithConfig(cfg))
+		require.NoError(t, err)
+
+		assert.False(t, SetInterval(time.Minute))
+	})
+}
@@ -26,12 +26,10 @@ import (
 	"fmt"
 	"net/http"
 
-	internalConfig "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/utils"
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/version"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
@@ -40,22 +38,14 @@ import (
 
 // OtelChiMiddleware returns middleware that will trace incoming requests for the chi web framework.
 // The service parameter should describe the name of the (virtual) server handling the request.
-func OtelChiMiddleware() func(http.Handler) http.Handler {
-	cfg := config{}
-	if cfg.TracerProvider == nil {
-		cfg.TracerProvider = otel.GetTracerProvider()
-	}
+//
+// The configuration is read from the environment, unless it is given with WithConfig.
+func OtelChiMiddleware(opts ...Option) func(http.Handler) http.Handler {
+	cfg := newConfig(opts)
 	tracer := cfg.TracerProvider.Tracer(
 		ScopeName,
 		oteltrace.WithInstrumentationVersion(version.Version()),
 	)
-	if cfg.Propagators == nil {
-		cfg.Propagators = otel.GetTextMapPropagator()
-	}
-	if cfg.MonitoringConfig == nil {
-		monitoringConfig := internalConfig.NewMonitoringConfig()
-		cfg.MonitoringConfig = monitoringConfig
-	}
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
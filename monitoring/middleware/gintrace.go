@@ -11,35 +11,25 @@ import (
 
 	"github.com/gin-gonic/gin"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
-	internalConfig "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/utils"
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/version"
 )
 
 // OtelGinMiddleware returns middleware that will trace incoming requests for the gin web framework.
 // The service parameter should describe the name of the (virtual) server handling the request.
-func OtelGinMiddleware() gin.HandlerFunc {
-	cfg := config{}
-	if cfg.TracerProvider == nil {
-		cfg.TracerProvider = otel.GetTracerProvider()
-	}
+//
+// The configuration is read from the environment, unless it is given with WithConfig.
+func OtelGinMiddleware(opts ...Option) gin.HandlerFunc {
+	cfg := newConfig(opts)
 	tracer := cfg.TracerProvider.Tracer(
 		ScopeName,
 		oteltrace.WithInstrumentationVersion(version.Version()),
 	)
-	if cfg.Propagators == nil {
-		cfg.Propagators = otel.GetTextMapPropagator()
-	}
-	if cfg.MonitoringConfig == nil {
-		monitoringConfig := internalConfig.NewMonitoringConfig()
-		cfg.MonitoringConfig = monitoringConfig
-	}
 
 	return func(c *gin.Context) {
 		for _, f := range cfg.Filters {
@@ -7,11 +7,13 @@ package middleware // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring
 
 import (
 	"net/http"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	internalConfig "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/option"
 )
 
 const (
@@ -27,6 +29,83 @@ type config struct {
 	Filters          []filter
 }
 
+// Option specifies instrumentation configuration options.
+type Option = option.Option
+
+// newConfig applies the options and fills in the defaults of the missing values.
+func newConfig(opts []Option) config {
+	cfg := config{
+		TracerProvider: option.TracerProvider(opts),
+		Propagators:    option.Propagators(opts),
+	}
+
+	// only the excluded paths of the process-wide configuration are changed by SetExcludedPaths
+	monitoringConfig, explicit := option.ExplicitConfig(opts)
+	if explicit {
+		cfg.MonitoringConfig = monitoringConfig
+	} else {
+		cfg.MonitoringConfig = internalConfig.NewMonitoringConfig()
+	}
+	cfg.Filters = append(cfg.Filters, excludedPathsFilter(cfg.MonitoringConfig.MiddlewareExcludedPaths(), !explicit))
+
+	return cfg
+}
+
+// WithTracerProvider specifies a tracer provider to use for creating a tracer.
+// If none is specified, the global provider is used.
+func WithTracerProvider(provider oteltrace.TracerProvider) Option {
+	return option.WithTracerProvider(provider)
+}
+
+// WithPropagators specifies propagators to use for extracting
+// information from the HTTP requests. If none are specified, global
+// ones will be used.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return option.WithPropagators(propagators)
+}
+
+// WithConfig uses the given configuration (see the monitoring/config package),
+// instead of the process-wide configuration read from the environment.
+func WithConfig(monitoringConfig internalConfig.Monitoring) Option {
+	return option.WithConfig(monitoringConfig)
+}
+
 // Filter is a predicate used to determine whether a given http.request should
 // be traced. A Filter must return true if the request should be traced.
 type filter func(*http.Request) bool
+
+// reloadedPaths overrides the excluded paths of the process-wide configuration,
+// once they are changed with SetExcludedPaths.
+var reloadedPaths atomic.Pointer[map[string]struct{}]
+
+// SetExcludedPaths changes the request paths which are not traced by the middlewares created without WithConfig,
+// without recreating them. It overrides the paths of the process-wide configuration.
+func SetExcludedPaths(paths []string) {
+	excluded := pathSet(paths)
+	reloadedPaths.Store(&excluded)
+}
+
+// pathSet returns the set of the given paths.
+func pathSet(paths []string) map[string]struct{} {
+	excluded := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		excluded[p] = struct{}{}
+	}
+	return excluded
+}
+
+// excludedPathsFilter returns a filter which rejects the requests to any of the given paths,
+// or to any of the paths set with SetExcludedPaths if the paths are reloadable.
+func excludedPathsFilter(paths []string, reloadable bool) filter {
+	excluded := pathSet(paths)
+
+	return func(r *http.Request) bool {
+		current := excluded
+		if reloaded := reloadedPaths.Load(); reloadable && reloaded != nil {
+			current = *reloaded
+		}
+
+		_, ok := current[r.URL.Path]
+		return !ok
+	}
+}
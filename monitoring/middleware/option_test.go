@@ -0,0 +1,180 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	internalConfig "github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+)
+
+// newTestOptions returns the options of a middleware which records its spans.
+func newTestOptions(t *testing.T) (*tracetest.SpanRecorder, []Option) {
+	t.Helper()
+
+	cfg, err := internalConfig.ParseMonitoringConfig(internalConfig.WithEnvironment(map[string]string{
+		"OTEL_SERVICE_NAME":              "test-service",
+		"OTEL_MIDDLEWARE_EXCLUDED_PATHS": "/healthz",
+	}))
+	require.NoError(t, err)
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	return sr, []Option{WithConfig(cfg), WithTracerProvider(tp)}
+}
+
+func TestOtelChiMiddleware(t *testing.T) {
+	sr, opts := newTestOptions(t)
+
+	router := chi.NewRouter()
+	router.Use(OtelChiMiddleware(opts...))
+	router.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /users/1", spans[0].Name())
+}
+
+func TestOtelGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sr, opts := newTestOptions(t)
+
+	router := gin.New()
+	router.Use(OtelGinMiddleware(opts...))
+	router.GET("/users/:id", func(c *gin.Context) {})
+	router.GET("/healthz", func(c *gin.Context) {})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+}
+
+func TestNewConfig(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	propagators := propagation.TraceContext{}
+
+	tests := []struct {
+		name                string
+		opts                []Option
+		expectedProvider    oteltrace.TracerProvider
+		expectedPropagators propagation.TextMapPropagator
+	}{
+		{
+			name:                "without options",
+			expectedProvider:    otel.GetTracerProvider(),
+			expectedPropagators: otel.GetTextMapPropagator(),
+		},
+		{
+			name:                "with a tracer provider and propagators",
+			opts:                []Option{WithTracerProvider(tp), WithPropagators(propagators)},
+			expectedProvider:    tp,
+			expectedPropagators: propagators,
+		},
+		{
+			name:                "with nil values",
+			opts:                []Option{WithTracerProvider(tp), WithTracerProvider(nil), WithPropagators(nil)},
+			expectedProvider:    tp,
+			expectedPropagators: otel.GetTextMapPropagator(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig(tt.opts)
+
+			assert.Equal(t, tt.expectedProvider, cfg.TracerProvider)
+			assert.Equal(t, tt.expectedPropagators, cfg.Propagators)
+		})
+	}
+}
+
+func TestSetExcludedPaths(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "test-service")
+	t.Setenv("OTEL_MIDDLEWARE_EXCLUDED_PATHS", "/healthz")
+	internalConfig.ResetMonitoringConfig()
+	t.Cleanup(func() {
+		reloadedPaths.Store(nil)
+		internalConfig.ResetMonitoringConfig()
+	})
+
+	tests := []struct {
+		name     string
+		explicit bool
+		expected string
+	}{
+		{
+			name:     "with the process-wide configuration",
+			expected: "GET /healthz",
+		},
+		{
+			name:     "with an explicit configuration",
+			explicit: true,
+			expected: "GET /ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reloadedPaths.Store(nil)
+
+			sr, opts := newTestOptions(t)
+			if !tt.explicit {
+				// without WithConfig
+				opts = opts[1:]
+			}
+
+			router := chi.NewRouter()
+			router.Use(OtelChiMiddleware(opts...))
+			router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+			router.Get("/ready", func(w http.ResponseWriter, r *http.Request) {})
+
+			SetExcludedPaths([]string{"/ready"})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.expected, spans[0].Name())
+		})
+	}
+}
@@ -0,0 +1,303 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package monitoring // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring"
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/logger"
+	"github.com/FLYR-Open-Source/flyr-lib-go/monitoring/meter"
+	"github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+)
+
+const (
+	// defaultShutdownTimeout is the default deadline for flushing the telemetry on shutdown.
+	defaultShutdownTimeout = 10 * time.Second
+	// defaultPollInterval is the default interval at which the configuration file is checked for changes.
+	defaultPollInterval = 10 * time.Second
+)
+
+// startConfig are the configurations which Start used.
+type startConfig struct {
+	logger     config.Logger
+	monitoring config.Monitoring
+}
+
+// started are the configurations of the last call to Start, or nil if the telemetry was not started with Start.
+var started atomic.Pointer[startConfig]
+
+// ShutdownFunc flushes and stops the telemetry started by Start.
+//
+// It is safe to call it more than once; only the first call has an effect.
+type ShutdownFunc func(ctx context.Context) error
+
+// options are the options of Start
+type options struct {
+	logger          bool
+	tracer          bool
+	meter           bool
+	shutdownTimeout time.Duration
+	handleSignals   bool
+	onSignal        func(os.Signal)
+	startupSummary  bool
+	config          *config.Monitoring
+	reload          bool
+	pollInterval    time.Duration
+}
+
+// Option configures Start
+type Option func(*options)
+
+// WithoutLogger skips the initialisation of the logger.
+func WithoutLogger() Option {
+	return func(o *options) {
+		o.logger = false
+	}
+}
+
+// WithoutTracer skips the initialisation of the tracer.
+func WithoutTracer() Option {
+	return func(o *options) {
+		o.tracer = false
+	}
+}
+
+// WithoutMeter skips the initialisation of the meter.
+func WithoutMeter() Option {
+	return func(o *options) {
+		o.meter = false
+	}
+}
+
+// WithoutStartupSummary skips the one-line summary of the effective configuration,
+// which is logged once the telemetry is started.
+func WithoutStartupSummary() Option {
+	return func(o *options) {
+		o.startupSummary = false
+	}
+}
+
+// WithConfig starts the tracer and the meter with the given configuration (see the monitoring/config package),
+// instead of the process-wide configuration read from the environment.
+func WithConfig(cfg config.Monitoring) Option {
+	return func(o *options) {
+		o.config = &cfg
+	}
+}
+
+// WithReload applies the changes of the configuration while the process is running (see Reload),
+// whenever the process receives SIGHUP, or the configuration file (see FLYR_CONFIG_FILE) is modified.
+// The configuration file is checked at every poll interval (10 seconds, if the interval is not positive).
+func WithReload(pollInterval time.Duration) Option {
+	return func(o *options) {
+		o.reload = true
+		o.pollInterval = pollInterval
+		if pollInterval <= 0 {
+			o.pollInterval = defaultPollInterval
+		}
+	}
+}
+
+// WithShutdownTimeout sets the deadline for flushing the telemetry on shutdown.
+// The default deadline is 10 seconds.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.shutdownTimeout = timeout
+	}
+}
+
+// WithSignalHandling shuts down the telemetry when the process receives SIGTERM or SIGINT.
+//
+// After the telemetry is flushed, onSignal is called with the received signal. If onSignal is nil,
+// the process exits with the conventional status code (128 + the signal number).
+func WithSignalHandling(onSignal func(os.Signal)) Option {
+	return func(o *options) {
+		o.handleSignals = true
+		o.onSignal = onSignal
+	}
+}
+
+// Start initialises the logger, the tracer and the meter from the environment configuration.
+//
+// It replaces the calls to logger.InitLogger(), tracer.StartDefaultTracer(...) and meter.StartDefaultMeter(...),
+// and returns a single ShutdownFunc that shuts down the tracer first and the meter afterwards,
+// within the shutdown deadline. The logger is kept working until the end, so any error during the
+// shutdown can still be logged.
+//
+// Once started, a one-line summary of the effective configuration is logged (see Diagnostics).
+//
+// If any of the signals fails to start, the already started signals are shut down and the error is returned.
+func Start(ctx context.Context, opts ...Option) (ShutdownFunc, error) {
+	o := options{
+		logger:          true,
+		tracer:          true,
+		meter:           true,
+		shutdownTimeout: defaultShutdownTimeout,
+		startupSummary:  true,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// a failed Start leaves no configuration to report or reload
+	started.Store(nil)
+
+	loggerCfg, err := config.ParseLoggerConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var monitoringCfg config.Monitoring
+	if o.config != nil {
+		monitoringCfg = *o.config
+	} else if monitoringCfg, err = config.ParseMonitoringConfig(); err != nil {
+		return nil, err
+	}
+
+	if o.logger {
+		logger.InitLogger(logger.WithConfig(monitoringCfg))
+	}
+
+	s, err := newSettings(loggerCfg, monitoringCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdowns := make([]func(context.Context) error, 0, 3)
+
+	if o.tracer {
+		if err := tracer.StartDefaultTracer(ctx, tracer.WithConfig(monitoringCfg)); err != nil {
+			return nil, err
+		}
+		shutdowns = append(shutdowns, tracer.ShutdownTracerProvider)
+	}
+
+	if o.meter {
+		if _, err := meter.StartDefaultMeter(ctx, meter.WithConfig(monitoringCfg)); err != nil {
+			return nil, errors.Join(err, newShutdownFunc(shutdowns, o.shutdownTimeout)(ctx))
+		}
+		shutdowns = append(shutdowns, meter.ShutdownMeterProvider)
+	}
+
+	// the configuration is only reported and reloaded once every signal is started
+	setSettings(s)
+	started.Store(&startConfig{logger: loggerCfg, monitoring: monitoringCfg})
+
+	if o.reload {
+		// the reload is stopped first, so no setting changes during the shutdown
+		shutdowns = append([]func(context.Context) error{watchReload(o.pollInterval)}, shutdowns...)
+	}
+
+	shutdown := newShutdownFunc(shutdowns, o.shutdownTimeout)
+
+	if o.startupSummary {
+		logger.Info(ctx, "telemetry started: "+newReport(loggerCfg, monitoringCfg).String())
+	}
+
+	if o.handleSignals {
+		stop := handleSignals(shutdown, o.onSignal)
+		flush := shutdown
+		shutdown = func(ctx context.Context) error {
+			stop()
+			return flush(ctx)
+		}
+	}
+
+	return shutdown, nil
+}
+
+// newShutdownFunc returns a ShutdownFunc that calls the given shutdown functions in order,
+// within the given deadline, and joins their errors.
+func newShutdownFunc(shutdowns []func(context.Context) error, timeout time.Duration) ShutdownFunc {
+	var (
+		once sync.Once
+		err  error
+	)
+
+	return func(ctx context.Context) error {
+		once.Do(func() {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			errs := make([]error, 0, len(shutdowns))
+			for _, shutdown := range shutdowns {
+				errs = append(errs, shutdown(ctx))
+			}
+			err = errors.Join(errs...)
+			if err != nil {
+				logger.Error(ctx, "failed to shutdown the telemetry", err)
+			}
+		})
+		return err
+	}
+}
+
+// handleSignals shuts down the telemetry when the process receives SIGTERM or SIGINT,
+// and then calls onSignal (or exits the process if onSignal is nil).
+//
+// The returned function stops the signal handling; it is called by the ShutdownFunc returned by Start,
+// so a normal shutdown doesn't leave the signals captured.
+func handleSignals(shutdown ShutdownFunc, onSignal func(os.Signal)) func() {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	go func() {
+		var sig os.Signal
+		select {
+		case sig = <-signals:
+		case <-done:
+			return
+		}
+		signal.Stop(signals)
+
+		_ = shutdown(context.Background())
+
+		if onSignal != nil {
+			onSignal(sig)
+			return
+		}
+
+		code := 1
+		if s, ok := sig.(syscall.Signal); ok {
+			code = 128 + int(s)
+		}
+		os.Exit(code)
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
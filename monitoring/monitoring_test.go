@@ -0,0 +1,191 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func setTestEnvironment(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "test-service")
+	t.Setenv("OTEL_EXPORTER_OTLP_TEST", "true")
+	config.ResetMonitoringConfig()
+	started.Store(nil)
+	t.Cleanup(func() {
+		config.ResetMonitoringConfig()
+		started.Store(nil)
+	})
+}
+
+func TestStart(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Starts the tracer and the meter", func(t *testing.T) {
+		setTestEnvironment(t)
+
+		shutdown, err := Start(ctx)
+		require.NoError(t, err)
+
+		assert.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+		assert.IsType(t, &sdkmetric.MeterProvider{}, otel.GetMeterProvider())
+
+		require.NoError(t, shutdown(ctx))
+		// a second shutdown has no effect
+		require.NoError(t, shutdown(ctx))
+	})
+
+	t.Run("Skips the disabled signals", func(t *testing.T) {
+		setTestEnvironment(t)
+		otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+		shutdown, err := Start(ctx, WithoutLogger(), WithoutMeter())
+		require.NoError(t, err)
+		require.NoError(t, shutdown(ctx))
+	})
+
+	t.Run("Starts with an explicit config", func(t *testing.T) {
+		cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+			"OTEL_SERVICE_NAME":       "explicit-service",
+			"OTEL_EXPORTER_OTLP_TEST": "true",
+		}))
+		require.NoError(t, err)
+
+		shutdown, err := Start(ctx, WithoutLogger(), WithConfig(cfg))
+		require.NoError(t, err)
+
+		assert.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+		require.NoError(t, shutdown(ctx))
+	})
+
+	t.Run("Returns the error of an invalid exporter", func(t *testing.T) {
+		setTestEnvironment(t)
+		shutdown, err := Start(ctx, WithoutStartupSummary())
+		require.NoError(t, err)
+		require.NoError(t, shutdown(ctx))
+
+		t.Setenv("OTEL_EXPORTER_OTLP_TEST", "false")
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "invalid")
+		config.ResetMonitoringConfig()
+
+		shutdown, err = Start(ctx)
+		require.Error(t, err)
+		assert.Nil(t, shutdown)
+
+		// the failed configuration is neither reported nor reloaded
+		assert.Nil(t, started.Load())
+		require.ErrorIs(t, Reload(ctx), ErrNotStarted)
+	})
+}
+
+func TestNewShutdownFunc(t *testing.T) {
+	t.Run("Calls the shutdowns in order and joins the errors", func(t *testing.T) {
+		calls := make([]string, 0, 2)
+		first := errors.New("first")
+		shutdown := newShutdownFunc([]func(context.Context) error{
+			func(context.Context) error { calls = append(calls, "tracer"); return first },
+			func(context.Context) error { calls = append(calls, "meter"); return nil },
+		}, time.Second)
+
+		err := shutdown(context.Background())
+		require.ErrorIs(t, err, first)
+		assert.Equal(t, []string{"tracer", "meter"}, calls)
+	})
+
+	t.Run("Applies the deadline", func(t *testing.T) {
+		shutdown := newShutdownFunc([]func(context.Context) error{
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}, 10*time.Millisecond)
+
+		err := shutdown(context.Background())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestHandleSignals(t *testing.T) {
+	t.Run("Signal received", func(t *testing.T) {
+		shutdownCalled := make(chan struct{})
+		received := make(chan os.Signal, 1)
+
+		stop := handleSignals(func(context.Context) error {
+			close(shutdownCalled)
+			return nil
+		}, func(sig os.Signal) {
+			received <- sig
+		})
+		defer stop()
+
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+
+		select {
+		case sig := <-received:
+			assert.Equal(t, syscall.SIGTERM, sig)
+			assert.NotPanics(t, func() { <-shutdownCalled })
+		case <-time.After(5 * time.Second):
+			t.Fatal("the signal was not handled")
+		}
+	})
+
+	t.Run("Stopped", func(t *testing.T) {
+		// keeps the test process alive when the signal is no longer handled
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM)
+		defer signal.Stop(signals)
+
+		shutdownCalled := make(chan struct{})
+		stop := handleSignals(func(context.Context) error {
+			close(shutdownCalled)
+			return nil
+		}, func(os.Signal) {})
+		stop()
+		stop()
+
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+
+		select {
+		case <-signals:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the signal was not received")
+		}
+		select {
+		case <-shutdownCalled:
+			t.Fatal("the telemetry was shut down after the signal handling was stopped")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,268 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package monitoring // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/logger"
+	"github.com/FLYR-Open-Source/flyr-lib-go/monitoring/meter"
+	"github.com/FLYR-Open-Source/flyr-lib-go/monitoring/middleware"
+	"github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+)
+
+// ErrNotStarted is returned by Reload when the telemetry was not started with Start.
+var ErrNotStarted = errors.New("telemetry not started")
+
+// settings are the telemetry settings which can be changed without restarting the process.
+type settings struct {
+	logLevel        string
+	packageLevels   map[string]string
+	samplingRatio   float64
+	metricsInterval time.Duration
+	excludedPaths   []string
+}
+
+// newSettings returns the reloadable settings of the given configurations.
+func newSettings(loggerCfg config.Logger, monitoringCfg config.Monitoring) (settings, error) {
+	ratio, err := monitoringCfg.TracesSamplingRatio()
+	if err != nil {
+		return settings{}, err
+	}
+
+	return settings{
+		logLevel:        loggerCfg.LogLevel(),
+		packageLevels:   loggerCfg.PackageLevels(),
+		samplingRatio:   ratio,
+		metricsInterval: monitoringCfg.MetricsInterval(),
+		excludedPaths:   monitoringCfg.MiddlewareExcludedPaths(),
+	}, nil
+}
+
+// loadSettings parses the configurations which Start used again, from the environment, the defaults
+// and the current configuration file, and returns their reloadable settings.
+func loadSettings(s *startConfig) (settings, error) {
+	loggerCfg, err := s.logger.Reparse()
+	if err != nil {
+		return settings{}, err
+	}
+
+	monitoringCfg, err := s.monitoring.Reparse()
+	if err != nil {
+		return settings{}, err
+	}
+
+	return newSettings(loggerCfg, monitoringCfg)
+}
+
+// changes returns a description of every setting which differs from the previous settings,
+// in the form "<variable>: <previous> -> <current>".
+func (s settings) changes(previous settings) []string {
+	var changes []string
+	add := func(name, previous, current string) {
+		if previous != current {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", name, previous, current))
+		}
+	}
+
+	add("LOG_LEVEL", previous.logLevel, s.logLevel)
+	add("LOG_PACKAGE_LEVELS", formatLevels(previous.packageLevels), formatLevels(s.packageLevels))
+	add("OTEL_TRACES_SAMPLER_ARG", fmt.Sprint(previous.samplingRatio), fmt.Sprint(s.samplingRatio))
+	add("OTEL_METRICS_INTERVAL_SECONDS", previous.metricsInterval.String(), s.metricsInterval.String())
+	add("OTEL_MIDDLEWARE_EXCLUDED_PATHS", strings.Join(previous.excludedPaths, ","), strings.Join(s.excludedPaths, ","))
+
+	return changes
+}
+
+// apply applies the settings to the logger, the default tracer and meter providers and the middlewares,
+// and returns the settings which took effect. The previous sampling ratio and interval are kept
+// if the default tracer and meter providers cannot change them (e.g. with the always_on sampler).
+func (s settings) apply(previous settings) settings {
+	logger.SetLevels(s.logLevel, s.packageLevels)
+	if !tracer.SetSamplingRatio(s.samplingRatio) {
+		s.samplingRatio = previous.samplingRatio
+	}
+	if !meter.SetInterval(s.metricsInterval) {
+		s.metricsInterval = previous.metricsInterval
+	}
+	middleware.SetExcludedPaths(s.excludedPaths)
+
+	return s
+}
+
+// formatLevels formats the per-package levels as a sorted, comma separated list of package=level.
+func formatLevels(levels map[string]string) string {
+	pairs := make([]string, 0, len(levels))
+	for _, pkg := range slices.Sorted(maps.Keys(levels)) {
+		pairs = append(pairs, pkg+"="+levels[pkg])
+	}
+	return strings.Join(pairs, ",")
+}
+
+var (
+	reloadMu sync.Mutex
+	// current are the settings which are currently applied.
+	current settings
+)
+
+// setSettings records the settings which are applied by Start.
+func setSettings(s settings) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	current = s
+}
+
+// Reload parses the configuration which Start used again, from the environment (or the environment given
+// to the configuration of WithConfig) and the configuration file (see FLYR_CONFIG_FILE),
+// and applies the settings which are safe to change while the process is running:
+// the log level, the per-package log levels, the trace sampling ratio, the metric export interval
+// and the paths excluded by the middlewares. Every change which took effect is logged.
+//
+// Any other setting (e.g. the exporters or the service name) requires a restart.
+//
+// If the configuration is invalid, nothing is applied and the error is returned.
+// If the telemetry was not started with Start, ErrNotStarted is returned.
+func Reload(ctx context.Context) error {
+	_, err := reload(ctx)
+	return err
+}
+
+// reload applies the reloadable settings and returns the changes.
+func reload(ctx context.Context) ([]string, error) {
+	start := started.Load()
+	if start == nil {
+		return nil, ErrNotStarted
+	}
+
+	s, err := loadSettings(start)
+	if err != nil {
+		return nil, err
+	}
+
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if len(s.changes(current)) == 0 {
+		return nil, nil
+	}
+
+	applied := s.apply(current)
+	changes := applied.changes(current)
+	current = applied
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	logger.Info(ctx, "telemetry configuration reloaded", "changes", changes)
+
+	return changes, nil
+}
+
+// watchReload calls Reload whenever the process receives SIGHUP, or the configuration file
+// (see FLYR_CONFIG_FILE) is modified, which is checked at every poll interval.
+//
+// It returns a function that stops the watch.
+func watchReload(pollInterval time.Duration) func(context.Context) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	last := statConfigFile()
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-signals:
+			case <-ticker.C:
+				stat := statConfigFile()
+				if stat.equal(last) {
+					continue
+				}
+				last = stat
+			case <-done:
+				return
+			}
+
+			ctx := context.Background()
+			if err := Reload(ctx); err != nil {
+				logger.Error(ctx, "failed to reload the telemetry configuration", err)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func(context.Context) error {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+			<-stopped
+		})
+		return nil
+	}
+}
+
+// fileStat identifies a version of the configuration file.
+type fileStat struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// equal returns whether both stats identify the same version of the configuration file.
+func (s fileStat) equal(other fileStat) bool {
+	return s.path == other.path && s.size == other.size && s.modTime.Equal(other.modTime)
+}
+
+// statConfigFile returns the current version of the configuration file,
+// or the zero value if there is no configuration file.
+func statConfigFile() fileStat {
+	path := os.Getenv(config.ConfigFileEnv)
+	if path == "" {
+		return fileStat{}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStat{path: path}
+	}
+
+	return fileStat{path: path, modTime: info.ModTime(), size: info.Size()}
+}
@@ -0,0 +1,229 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package monitoring // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring"
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile writes the configuration file and points FLYR_CONFIG_FILE to it.
+func writeConfigFile(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv(config.ConfigFileEnv, path)
+}
+
+func TestSettingsChanges(t *testing.T) {
+	previous := settings{
+		logLevel:        "info",
+		samplingRatio:   1,
+		metricsInterval: time.Minute,
+	}
+
+	assert.Empty(t, previous.changes(previous))
+
+	current := settings{
+		logLevel:        "debug",
+		packageLevels:   map[string]string{"github.com/acme/http": "warn", "github.com/acme/db": "debug"},
+		samplingRatio:   0.5,
+		metricsInterval: 10 * time.Second,
+		excludedPaths:   []string{"/healthz", "/ready"},
+	}
+
+	assert.Equal(t, []string{
+		"LOG_LEVEL: info -> debug",
+		"LOG_PACKAGE_LEVELS:  -> github.com/acme/db=debug,github.com/acme/http=warn",
+		"OTEL_TRACES_SAMPLER_ARG: 1 -> 0.5",
+		"OTEL_METRICS_INTERVAL_SECONDS: 1m0s -> 10s",
+		"OTEL_MIDDLEWARE_EXCLUDED_PATHS:  -> /healthz,/ready",
+	}, current.changes(previous))
+}
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Applies the changes of the configuration file", func(t *testing.T) {
+		setTestEnvironment(t)
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, path, "logger:\n  level: info\n")
+
+		shutdown, err := Start(ctx, WithoutStartupSummary())
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, shutdown(ctx)) })
+
+		changes, err := reload(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+
+		writeConfigFile(t, path, "logger:\n  level: debug\ntracer:\n  sampling_ratio: 0.5\n")
+
+		changes, err = reload(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"LOG_LEVEL: info -> debug",
+			"OTEL_TRACES_SAMPLER_ARG: 1 -> 0.5",
+		}, changes)
+	})
+
+	t.Run("Reverts the settings removed from the configuration file", func(t *testing.T) {
+		setTestEnvironment(t)
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, path, "logger:\n  level: debug\n  package_levels:\n    github.com/acme/db: warn\n")
+
+		shutdown, err := Start(ctx, WithoutStartupSummary())
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, shutdown(ctx)) })
+
+		writeConfigFile(t, path, "logger: {}\n")
+
+		changes, err := reload(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"LOG_LEVEL: debug -> info",
+			"LOG_PACKAGE_LEVELS: github.com/acme/db=warn -> ",
+		}, changes)
+	})
+
+	t.Run("Keeps the configuration given to Start", func(t *testing.T) {
+		setTestEnvironment(t)
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, path, "logger:\n  level: info\n")
+
+		cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+			"OTEL_SERVICE_NAME":              "explicit-service",
+			"OTEL_EXPORTER_OTLP_TEST":        "true",
+			"OTEL_METRICS_INTERVAL_SECONDS":  "5",
+			"OTEL_MIDDLEWARE_EXCLUDED_PATHS": "/healthz",
+		}))
+		require.NoError(t, err)
+
+		shutdown, err := Start(ctx, WithoutStartupSummary(), WithConfig(cfg))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, shutdown(ctx)) })
+
+		writeConfigFile(t, path, "logger:\n  level: debug\n")
+
+		changes, err := reload(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"LOG_LEVEL: info -> debug"}, changes)
+	})
+
+	t.Run("Reports only the settings which took effect", func(t *testing.T) {
+		setTestEnvironment(t)
+		t.Setenv("OTEL_TRACES_SAMPLER", "always_on")
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, path, "tracer:\n  sampling_ratio: 1\n")
+
+		shutdown, err := Start(ctx, WithoutStartupSummary())
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, shutdown(ctx)) })
+
+		writeConfigFile(t, path, "tracer:\n  sampling_ratio: 0.5\n")
+
+		changes, err := reload(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Equal(t, float64(1), current.samplingRatio)
+	})
+
+	t.Run("Without Start", func(t *testing.T) {
+		setTestEnvironment(t)
+
+		require.ErrorIs(t, Reload(ctx), ErrNotStarted)
+	})
+
+	t.Run("Keeps the settings of an invalid configuration", func(t *testing.T) {
+		setTestEnvironment(t)
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, path, "tracer:\n  sampling_ratio: 0.5\n")
+
+		shutdown, err := Start(ctx, WithoutStartupSummary())
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, shutdown(ctx)) })
+
+		writeConfigFile(t, path, "tracer:\n  sampling_ratio: 2\n")
+
+		require.ErrorIs(t, Reload(ctx), config.ErrInvalidSamplerConfig)
+		assert.Equal(t, 0.5, current.samplingRatio)
+	})
+
+	t.Run("Reloads when the configuration file is modified", func(t *testing.T) {
+		setTestEnvironment(t)
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeConfigFile(t, path, "meter:\n  interval_seconds: 60\n")
+
+		shutdown, err := Start(ctx, WithoutStartupSummary(), WithReload(10*time.Millisecond))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, shutdown(ctx)) })
+
+		writeConfigFile(t, path, "meter:\n  interval_seconds: 5\n")
+
+		assert.Eventually(t, func() bool {
+			reloadMu.Lock()
+			defer reloadMu.Unlock()
+			return current.metricsInterval == 5*time.Second
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestFileStatEqual(t *testing.T) {
+	modTime := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	stat := fileStat{path: "config.yaml", modTime: modTime, size: 10}
+
+	tests := []struct {
+		name     string
+		other    fileStat
+		expected bool
+	}{
+		{
+			name:     "with the same instant in another location",
+			other:    fileStat{path: "config.yaml", modTime: modTime.In(time.FixedZone("CEST", 2*60*60)), size: 10},
+			expected: true,
+		},
+		{
+			name:  "with another modification time",
+			other: fileStat{path: "config.yaml", modTime: modTime.Add(time.Second), size: 10},
+		},
+		{
+			name:  "with another size",
+			other: fileStat{path: "config.yaml", modTime: modTime, size: 11},
+		},
+		{
+			name:  "with another path",
+			other: fileStat{path: "other.yaml", modTime: modTime, size: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, stat.equal(tt.other))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	internalSpan "github.com/FLYR-Open-Source/flyr-lib-go/internal/span"
+)
+
+// Classification describes how an error is recorded in a span (see SetErrorClassifier)
+type Classification = internalSpan.Classification
+
+// ClassifiedError is implemented by the errors which describe how they are recorded in a span
+type ClassifiedError = internalSpan.ClassifiedError
+
+// ErrorClassifier decides how the errors are recorded in the spans
+type ErrorClassifier = internalSpan.ErrorClassifier
+
+// ErrorClassifierFunc is a function which implements ErrorClassifier
+type ErrorClassifierFunc = internalSpan.ErrorClassifierFunc
+
+// SetErrorClassifier replaces the classifier which decides how the errors given to Span.EndWithError
+// (and returned to Run and RunValue, or logged with logger.Error) are recorded: whether they set the Error status of the span,
+// or are only recorded as an event, and which attributes are added to the span.
+//
+// If the classifier is nil, the default classifier is restored (see DefaultErrorClassification).
+func SetErrorClassifier(classifier ErrorClassifier) {
+	internalSpan.SetErrorClassifier(classifier)
+}
+
+// DefaultErrorClassification returns the classification of the error by the default classifier.
+//
+// An error which implements ClassifiedError (anywhere in the chain of wrapped errors) is classified by itself,
+// context.Canceled is only recorded as an event, and any other error sets the Error status.
+// A custom classifier can fall back to it for the errors it does not know.
+func DefaultErrorClassification(err error) Classification {
+	return internalSpan.DefaultClassification(err)
+}
+
+// NewClassifiedError wraps the error with the given classification, so the error type
+// does not have to implement ClassifiedError. It returns nil if the error is nil.
+func NewClassifiedError(err error, classification Classification) error {
+	return internalSpan.NewClassifiedError(err, classification)
+}
@@ -0,0 +1,165 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// instrumentationName is the instrumentation scope name of the tracer metrics
+	instrumentationName = "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+	// droppedSpansCounterName is the name of the counter incremented for every span dropped because the queue is full
+	droppedSpansCounterName = "traces.processor.spans.dropped"
+	// queueSizeGaugeName is the name of the gauge of the spans waiting to be exported
+	queueSizeGaugeName = "traces.processor.queue.size"
+	// queueCapacityGaugeName is the name of the gauge of the maximum number of spans waiting to be exported
+	queueCapacityGaugeName = "traces.processor.queue.capacity"
+	// exporterAttributeKey is the attribute of the processor metrics with the name of the exporter
+	exporterAttributeKey = "exporter"
+)
+
+// batchProcessor is a batch span processor which counts the spans waiting to be exported.
+//
+// The queue is bounded by the maximum queue size, including the batch which is being exported,
+// so the spans which do not fit are dropped here (instead of in the wrapped processor) and counted.
+type batchProcessor struct {
+	sdktrace.SpanProcessor
+	queued       atomic.Int64
+	stopped      atomic.Bool
+	capacity     int64
+	attributes   metric.MeasurementOption
+	dropped      metric.Int64Counter
+	registration metric.Registration
+}
+
+// newBatchProcessor returns a batchProcessor of the exporter, which reports its metrics
+// to the given MeterProvider with the name of the exporter.
+func newBatchProcessor(exporter sdktrace.SpanExporter, name string, cfg config.BatchProcessor, mp metric.MeterProvider) (*batchProcessor, error) {
+	p := &batchProcessor{
+		capacity:   int64(cfg.MaxQueueSize),
+		attributes: metric.WithAttributeSet(attribute.NewSet(attribute.String(exporterAttributeKey, name))),
+	}
+	p.SpanProcessor = sdktrace.NewBatchSpanProcessor(
+		queueExporter{SpanExporter: exporter, processor: p},
+		sdktrace.WithMaxQueueSize(cfg.MaxQueueSize),
+		sdktrace.WithMaxExportBatchSize(cfg.MaxExportBatchSize),
+		sdktrace.WithBatchTimeout(cfg.ScheduleDelay),
+		sdktrace.WithExportTimeout(cfg.ExportTimeout),
+	)
+
+	meter := mp.Meter(instrumentationName, metric.WithInstrumentationVersion(version.Version()))
+
+	var err error
+	p.dropped, err = meter.Int64Counter(
+		droppedSpansCounterName,
+		metric.WithDescription("The number of spans dropped because the queue of the batch span processor is full."),
+		metric.WithUnit("{span}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := meter.Int64ObservableGauge(
+		queueSizeGaugeName,
+		metric.WithDescription("The number of spans waiting to be exported by the batch span processor."),
+		metric.WithUnit("{span}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	capacity, err := meter.Int64ObservableGauge(
+		queueCapacityGaugeName,
+		metric.WithDescription("The maximum number of spans waiting to be exported by the batch span processor."),
+		metric.WithUnit("{span}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.registration, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(size, p.queued.Load(), p.attributes)
+		o.ObserveInt64(capacity, p.capacity, p.attributes)
+		return nil
+	}, size, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// OnEnd queues the sampled span for export, or drops it if the queue is full.
+// The spans ended after the shutdown are ignored, like the wrapped processor does.
+func (p *batchProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() || p.stopped.Load() {
+		return
+	}
+
+	if p.queued.Add(1) > p.capacity {
+		p.queued.Add(-1)
+		p.dropped.Add(context.Background(), 1, p.attributes)
+		return
+	}
+
+	p.SpanProcessor.OnEnd(s)
+}
+
+// Shutdown stops reporting the queue size, and exports the queued spans.
+func (p *batchProcessor) Shutdown(ctx context.Context) error {
+	p.stopped.Store(true)
+	return errors.Join(p.registration.Unregister(), p.SpanProcessor.Shutdown(ctx))
+}
+
+// queueExporter releases the exported spans from the queue of the processor.
+type queueExporter struct {
+	sdktrace.SpanExporter
+	processor *batchProcessor
+}
+
+// ExportSpans exports the spans, which are released from the queue whether the export succeeds or not.
+func (e queueExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	defer e.processor.queued.Add(-int64(len(spans)))
+
+	return e.SpanExporter.ExportSpans(ctx, spans)
+}
+
+// spanLimits converts the span limits of the configuration.
+func spanLimits(cfg config.SpanLimits) sdktrace.SpanLimits {
+	return sdktrace.SpanLimits{
+		AttributeValueLengthLimit:   cfg.AttributeValueLength,
+		AttributeCountLimit:         cfg.AttributeCount,
+		EventCountLimit:             cfg.EventCount,
+		LinkCountLimit:              cfg.LinkCount,
+		AttributePerEventCountLimit: cfg.AttributePerEventCount,
+		AttributePerLinkCountLimit:  cfg.AttributePerLinkCount,
+	}
+}
@@ -0,0 +1,147 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// blockingExporter blocks every export until it is released.
+type blockingExporter struct {
+	*tracetest.InMemoryExporter
+	release chan struct{}
+}
+
+// ExportSpans waits for the release of the exporter, and exports the spans.
+func (e blockingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	<-e.release
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+// Shutdown keeps the exported spans, which the in-memory exporter would reset.
+func (e blockingExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// int64Metric returns the value of the int64 metric of the given name.
+func int64Metric(t *testing.T, reader sdkmetric.Reader, name string) int64 {
+	t.Helper()
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				return data.DataPoints[0].Value
+			case metricdata.Gauge[int64]:
+				return data.DataPoints[0].Value
+			}
+		}
+	}
+
+	t.Fatalf("metric %s not found", name)
+	return 0
+}
+
+func TestBatchProcessor(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	exporter := blockingExporter{InMemoryExporter: tracetest.NewInMemoryExporter(), release: make(chan struct{})}
+	processor, err := newBatchProcessor(exporter, "otlp/grpc", config.BatchProcessor{
+		MaxQueueSize:       2,
+		MaxExportBatchSize: 1,
+		ScheduleDelay:      time.Millisecond,
+		ExportTimeout:      time.Second,
+	}, mp)
+	require.NoError(t, err)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor))
+	tracer := tp.Tracer("test")
+	for range 5 {
+		_, span := tracer.Start(ctx, "test-span")
+		span.End()
+	}
+
+	assert.Equal(t, int64(3), int64Metric(t, reader, droppedSpansCounterName))
+	assert.Equal(t, int64(2), int64Metric(t, reader, queueSizeGaugeName))
+	assert.Equal(t, int64(2), int64Metric(t, reader, queueCapacityGaugeName))
+
+	close(exporter.release)
+	require.NoError(t, tp.Shutdown(ctx))
+	assert.Len(t, exporter.GetSpans(), 2)
+	assert.Zero(t, processor.queued.Load())
+
+	// the spans ended after the shutdown are not queued
+	processor.OnEnd(tracetest.SpanStub{
+		SpanContext: oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+			TraceID:    oteltrace.TraceID{1},
+			SpanID:     oteltrace.SpanID{1},
+			TraceFlags: oteltrace.FlagsSampled,
+		}),
+	}.Snapshot())
+	assert.Zero(t, processor.queued.Load())
+}
+
+func TestSpanLimits(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithRawSpanLimits(spanLimits(config.SpanLimits{
+			AttributeValueLength:   4,
+			AttributeCount:         1,
+			EventCount:             -1,
+			LinkCount:              -1,
+			AttributePerEventCount: -1,
+			AttributePerLinkCount:  -1,
+		})),
+	)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "test-span")
+	span.SetAttributes(attribute.String("first", strings.Repeat("a", 10)), attribute.String("second", "b"))
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("first", "aaaa")}, spans[0].Attributes)
+	assert.Equal(t, 1, spans[0].DroppedAttributes)
+}
@@ -0,0 +1,181 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// cloudTraceHeader is the header of the Google Cloud Trace context, sent by the GCP load balancers.
+const cloudTraceHeader = "x-cloud-trace-context"
+
+// cloudTracePropagator propagates the trace context in the `X-Cloud-Trace-Context` header,
+// whose format is `TRACE_ID/SPAN_ID;o=OPTIONS`, with the span id as a decimal number.
+type cloudTracePropagator struct{}
+
+// Inject sets the trace context of the span of the context in the carrier.
+func (cloudTracePropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+
+	spanID := sc.SpanID()
+	sampled := 0
+	if sc.IsSampled() {
+		sampled = 1
+	}
+	carrier.Set(cloudTraceHeader, fmt.Sprintf("%s/%d;o=%d", sc.TraceID(), binary.BigEndian.Uint64(spanID[:]), sampled))
+}
+
+// Extract returns a copy of the context with the remote span context of the carrier, if the header is valid.
+func (cloudTracePropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	value := carrier.Get(cloudTraceHeader)
+	if value == "" {
+		return ctx
+	}
+
+	ids, options, _ := strings.Cut(value, ";")
+	traceIDHex, spanIDDec, ok := strings.Cut(ids, "/")
+	if !ok {
+		return ctx
+	}
+
+	var traceID trace.TraceID
+	b, err := hex.DecodeString(traceIDHex)
+	if err != nil || len(b) != len(traceID) {
+		return ctx
+	}
+	copy(traceID[:], b)
+
+	id, err := strconv.ParseUint(spanIDDec, 10, 64)
+	if err != nil {
+		return ctx
+	}
+	var spanID trace.SpanID
+	binary.BigEndian.PutUint64(spanID[:], id)
+
+	var flags trace.TraceFlags
+	if options == "o=1" {
+		flags = trace.FlagsSampled
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+		Remote:     true,
+	})
+	if !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Fields returns the header of the propagator.
+func (cloudTracePropagator) Fields() []string {
+	return []string{cloudTraceHeader}
+}
+
+// newPropagatorOf returns the propagator of the given name (see config.SupportedPropagators).
+func newPropagatorOf(name string) propagation.TextMapPropagator {
+	switch name {
+	case config.PropagatorBaggage:
+		return propagation.Baggage{}
+	case config.PropagatorB3:
+		return b3.New(b3.WithInjectEncoding(b3.B3SingleHeader))
+	case config.PropagatorB3Multi:
+		return b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader))
+	case config.PropagatorJaeger:
+		return jaeger.Jaeger{}
+	case config.PropagatorCloudTrace:
+		return cloudTracePropagator{}
+	default:
+		return propagation.TraceContext{}
+	}
+}
+
+// compositePropagator injects the context with the configured propagators,
+// and extracts it from any of the supported formats.
+type compositePropagator struct {
+	inject  propagation.TextMapPropagator
+	extract propagation.TextMapPropagator
+}
+
+// Inject sets the context in the carrier with the configured propagators.
+func (p compositePropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	p.inject.Inject(ctx, carrier)
+}
+
+// Extract returns a copy of the context with the values of the carrier, in any of the supported formats.
+func (p compositePropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return p.extract.Extract(ctx, carrier)
+}
+
+// Fields returns the headers of all the supported formats.
+func (p compositePropagator) Fields() []string {
+	return p.extract.Fields()
+}
+
+// newPropagator returns the propagator of the default tracer provider, which injects the context with the
+// propagators of `OTEL_PROPAGATORS`, and extracts it from any of the supported formats.
+//
+// When the carrier holds more than one format, the configured propagators take precedence
+// (the last one first, as in a composite propagator), followed by the other formats.
+// If the propagation is disabled, the context is neither injected nor extracted.
+func newPropagator(names []string) propagation.TextMapPropagator {
+	if len(names) == 0 {
+		return propagation.NewCompositeTextMapPropagator()
+	}
+
+	inject := make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		inject = append(inject, newPropagatorOf(name))
+	}
+
+	// a composite propagator applies its propagators in order, so the last valid context wins
+	extract := make([]propagation.TextMapPropagator, 0, len(config.SupportedPropagators))
+	for _, name := range slices.Backward(config.SupportedPropagators) {
+		if !slices.Contains(names, name) {
+			extract = append(extract, newPropagatorOf(name))
+		}
+	}
+	extract = append(extract, inject...)
+
+	return compositePropagator{
+		inject:  propagation.NewCompositeTextMapPropagator(inject...),
+		extract: propagation.NewCompositeTextMapPropagator(extract...),
+	}
+}
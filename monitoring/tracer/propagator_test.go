@@ -0,0 +1,167 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// remoteSpanContext returns a context with a sampled remote span context.
+func remoteSpanContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	return trace.ContextWithRemoteSpanContext(context.Background(), sc), sc
+}
+
+func TestCloudTracePropagator(t *testing.T) {
+	ctx, sc := remoteSpanContext(t)
+
+	t.Run("injects the header", func(t *testing.T) {
+		carrier := propagation.MapCarrier{}
+		cloudTracePropagator{}.Inject(ctx, carrier)
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736/67667974448284343;o=1", carrier.Get(cloudTraceHeader))
+	})
+
+	t.Run("extracts the header", func(t *testing.T) {
+		carrier := propagation.MapCarrier{cloudTraceHeader: "4bf92f3577b34da6a3ce929d0e0e4736/67667974448284343;o=1"}
+		extracted := trace.SpanContextFromContext(cloudTracePropagator{}.Extract(context.Background(), carrier))
+
+		assert.Equal(t, sc, extracted)
+	})
+
+	t.Run("extracts the header without options", func(t *testing.T) {
+		carrier := propagation.MapCarrier{cloudTraceHeader: "4bf92f3577b34da6a3ce929d0e0e4736/67667974448284343"}
+		extracted := trace.SpanContextFromContext(cloudTracePropagator{}.Extract(context.Background(), carrier))
+
+		assert.True(t, extracted.IsValid())
+		assert.False(t, extracted.IsSampled())
+	})
+
+	for _, value := range []string{
+		"4bf92f3577b34da6a3ce929d0e0e4736",
+		"not-a-trace-id/67667974448284343;o=1",
+		"4bf92f3577b34da6a3ce929d0e0e4736/span;o=1",
+		"4bf92f3577b34da6a3ce929d0e0e4736/0;o=1",
+	} {
+		t.Run("ignores invalid header "+value, func(t *testing.T) {
+			carrier := propagation.MapCarrier{cloudTraceHeader: value}
+			extracted := trace.SpanContextFromContext(cloudTracePropagator{}.Extract(context.Background(), carrier))
+
+			assert.False(t, extracted.IsValid())
+		})
+	}
+}
+
+func TestNewPropagator(t *testing.T) {
+	ctx, sc := remoteSpanContext(t)
+
+	t.Run("injects the configured formats", func(t *testing.T) {
+		carrier := propagation.MapCarrier{}
+		newPropagator([]string{"b3multi", "cloudtrace"}).Inject(ctx, carrier)
+
+		assert.ElementsMatch(t, []string{"x-b3-traceid", "x-b3-spanid", "x-b3-sampled", cloudTraceHeader}, carrier.Keys())
+	})
+
+	tests := []struct {
+		name    string
+		carrier propagation.MapCarrier
+	}{
+		{
+			name:    "tracecontext",
+			carrier: propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		},
+		{
+			name:    "b3",
+			carrier: propagation.MapCarrier{"b3": "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"},
+		},
+		{
+			name: "b3multi",
+			carrier: propagation.MapCarrier{
+				"x-b3-traceid": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"x-b3-spanid":  "00f067aa0ba902b7",
+				"x-b3-sampled": "1",
+			},
+		},
+		{
+			name:    "jaeger",
+			carrier: propagation.MapCarrier{"uber-trace-id": "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1"},
+		},
+		{
+			name:    "cloudtrace",
+			carrier: propagation.MapCarrier{cloudTraceHeader: "4bf92f3577b34da6a3ce929d0e0e4736/67667974448284343;o=1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("extracts "+tt.name, func(t *testing.T) {
+			extracted := trace.SpanContextFromContext(newPropagator([]string{"tracecontext", "baggage"}).Extract(context.Background(), tt.carrier))
+
+			assert.Equal(t, sc.TraceID(), extracted.TraceID())
+			assert.Equal(t, sc.SpanID(), extracted.SpanID())
+			assert.True(t, extracted.IsSampled())
+			assert.True(t, extracted.IsRemote())
+		})
+	}
+
+	t.Run("configured formats take precedence", func(t *testing.T) {
+		carrier := propagation.MapCarrier{
+			"traceparent":    "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			cloudTraceHeader: "0af7651916cd43dd8448eb211c80319c/1;o=1",
+		}
+
+		extracted := trace.SpanContextFromContext(newPropagator([]string{"cloudtrace"}).Extract(context.Background(), carrier))
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", extracted.TraceID().String())
+
+		extracted = trace.SpanContextFromContext(newPropagator([]string{"tracecontext"}).Extract(context.Background(), carrier))
+		assert.Equal(t, sc.TraceID(), extracted.TraceID())
+	})
+
+	t.Run("none disables the propagation", func(t *testing.T) {
+		propagator := newPropagator([]string{})
+
+		carrier := propagation.MapCarrier{}
+		propagator.Inject(ctx, carrier)
+		assert.Empty(t, carrier.Keys())
+
+		carrier = propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+		assert.False(t, trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier)).IsValid())
+	})
+}
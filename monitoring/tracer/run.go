@@ -0,0 +1,80 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	"context"
+)
+
+// Run runs the function within a new span with the given name and kind, using the default tracer.
+//
+// The span is started with the caller's information, and it is ended when the function returns:
+// successfully if the function returns no error, or with the returned error otherwise.
+// If the function panics, the panic is recorded in the span with its stack trace, and the function
+// panics again once the span is ended.
+//
+// It returns the error of the function.
+func Run(ctx context.Context, name string, kind SpanKind, fn func(ctx context.Context) error) error {
+	_, err := run(ctx, name, kind, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// RunValue runs the function within a new span with the given name and kind, using the default tracer,
+// as Run does.
+//
+// It returns the value and the error of the function.
+func RunValue[T any](ctx context.Context, name string, kind SpanKind, fn func(ctx context.Context) (T, error)) (T, error) {
+	return run(ctx, name, kind, fn)
+}
+
+// run runs the function within a new span, and ends the span with the result of the function.
+func run[T any](ctx context.Context, name string, kind SpanKind, fn func(ctx context.Context) (T, error)) (value T, err error) {
+	ctx, span := defaultTracer.startSpan(ctx, name, kind, nil)
+
+	returned := false
+	defer func() {
+		if returned {
+			if err != nil {
+				span.EndWithError(err)
+			} else {
+				span.EndSuccessfully()
+			}
+			return
+		}
+
+		// the function panicked, or called runtime.Goexit (in which case nothing is recovered)
+		if recovered := recover(); recovered != nil {
+			span.EndWithPanic(recovered)
+			panic(recovered)
+		}
+		if span.Span != nil {
+			span.End()
+		}
+	}()
+
+	value, err = fn(ctx)
+	returned = true
+	return value, err
+}
@@ -0,0 +1,161 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+)
+
+// startRecordingTracer sets a default tracer which records the ended spans.
+func startRecordingTracer(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tracer.StarCustomTracer(tp.Tracer("test-tracer"))
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+	})
+
+	return sr
+}
+
+func TestRun(t *testing.T) {
+	t.Run("ends the span successfully", func(t *testing.T) {
+		sr := startRecordingTracer(t)
+
+		var spanCtx oteltrace.SpanContext
+		err := tracer.Run(context.Background(), "test-span", oteltrace.SpanKindServer, func(ctx context.Context) error {
+			spanCtx = oteltrace.SpanContextFromContext(ctx)
+			return nil
+		})
+		require.NoError(t, err)
+
+		ended := sr.Ended()
+		require.Len(t, ended, 1)
+		assert.Equal(t, "test-span", ended[0].Name())
+		assert.Equal(t, oteltrace.SpanKindServer, ended[0].SpanKind())
+		assert.Equal(t, spanCtx, ended[0].SpanContext())
+		assert.Equal(t, codes.Ok, ended[0].Status().Code)
+
+		got := attrsToMap(ended[0].Attributes())
+		assert.True(t, strings.HasSuffix(got["code.filepath"], "run_test.go"), "the caller must be the test, got %s", got["code.filepath"])
+	})
+
+	t.Run("ends the span with the error", func(t *testing.T) {
+		sr := startRecordingTracer(t)
+		expected := errors.New("test error")
+
+		err := tracer.Run(context.Background(), "test-span", oteltrace.SpanKindInternal, func(ctx context.Context) error {
+			return expected
+		})
+		require.ErrorIs(t, err, expected)
+
+		ended := sr.Ended()
+		require.Len(t, ended, 1)
+		assert.Equal(t, codes.Error, ended[0].Status().Code)
+		assert.Equal(t, "test error", ended[0].Status().Description)
+		require.Len(t, ended[0].Events(), 1)
+		assert.Equal(t, "exception", ended[0].Events()[0].Name)
+	})
+
+	t.Run("records a canceled context as an event only", func(t *testing.T) {
+		sr := startRecordingTracer(t)
+
+		err := tracer.Run(context.Background(), "test-span", oteltrace.SpanKindInternal, func(ctx context.Context) error {
+			return context.Canceled
+		})
+		require.ErrorIs(t, err, context.Canceled)
+
+		ended := sr.Ended()
+		require.Len(t, ended, 1)
+		assert.Equal(t, codes.Unset, ended[0].Status().Code)
+		require.Len(t, ended[0].Events(), 1)
+	})
+
+	t.Run("records the panic and panics again", func(t *testing.T) {
+		sr := startRecordingTracer(t)
+
+		require.PanicsWithValue(t, "boom", func() {
+			_ = tracer.Run(context.Background(), "test-span", oteltrace.SpanKindInternal, func(ctx context.Context) error {
+				panic("boom")
+			})
+		})
+
+		ended := sr.Ended()
+		require.Len(t, ended, 1)
+		assert.Equal(t, codes.Error, ended[0].Status().Code)
+		assert.Equal(t, "panic: boom", ended[0].Status().Description)
+
+		require.Len(t, ended[0].Events(), 1)
+		got := attrsToMap(ended[0].Events()[0].Attributes)
+		assert.Equal(t, "panic: boom", got["exception.message"])
+		assert.Contains(t, got["exception.stacktrace"], "run_test.go")
+	})
+}
+
+func TestRunValue(t *testing.T) {
+	t.Run("returns the value", func(t *testing.T) {
+		sr := startRecordingTracer(t)
+
+		value, err := tracer.RunValue(context.Background(), "test-span", oteltrace.SpanKindClient, func(ctx context.Context) (int, error) {
+			return 42, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 42, value)
+
+		ended := sr.Ended()
+		require.Len(t, ended, 1)
+		assert.Equal(t, codes.Ok, ended[0].Status().Code)
+
+		got := attrsToMap(ended[0].Attributes())
+		assert.True(t, strings.HasSuffix(got["code.filepath"], "run_test.go"), "the caller must be the test, got %s", got["code.filepath"])
+	})
+
+	t.Run("records the panic of an error", func(t *testing.T) {
+		sr := startRecordingTracer(t)
+		expected := errors.New("test error")
+
+		require.PanicsWithError(t, "test error", func() {
+			_, _ = tracer.RunValue(context.Background(), "test-span", oteltrace.SpanKindInternal, func(ctx context.Context) (string, error) {
+				panic(expected)
+			})
+		})
+
+		ended := sr.Ended()
+		require.Len(t, ended, 1)
+		assert.Equal(t, "panic: test error", ended[0].Status().Description)
+	})
+}
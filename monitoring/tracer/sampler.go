@@ -0,0 +1,219 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ratioSampler samples a ratio of the traces, based on the trace id.
+// The ratio can be changed while the sampler is in use.
+type ratioSampler struct {
+	ratio   atomic.Pointer[float64]
+	sampler atomic.Pointer[sdktrace.Sampler]
+}
+
+// newRatioSampler returns a ratioSampler of the given ratio.
+func newRatioSampler(ratio float64) *ratioSampler {
+	s := &ratioSampler{}
+	s.setRatio(ratio)
+	return s
+}
+
+// setRatio changes the ratio of the sampled traces.
+func (s *ratioSampler) setRatio(ratio float64) {
+	sampler := sdktrace.TraceIDRatioBased(ratio)
+	s.sampler.Store(&sampler)
+	s.ratio.Store(&ratio)
+}
+
+// ShouldSample returns the decision of the sampler of the current ratio.
+func (s *ratioSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return (*s.sampler.Load()).ShouldSample(p)
+}
+
+// Description returns the description of the sampler.
+func (s *ratioSampler) Description() string {
+	return fmt.Sprintf("ReloadableTraceIDRatioBased{%g}", *s.ratio.Load())
+}
+
+// SetSamplingRatio changes the ratio of the traces which are sampled by the default tracer provider,
+// without restarting it. The ratio is clamped between 0 (no traces) and 1 (all the traces).
+//
+// It only applies to the traceidratio and parentbased_traceidratio samplers (see `OTEL_TRACES_SAMPLER`),
+// and it returns whether the ratio was changed.
+func SetSamplingRatio(ratio float64) bool {
+	tp := defaultTracer.provider
+	if tp == nil || tp.samplingRatio == nil {
+		return false
+	}
+
+	tp.samplingRatio.setRatio(min(max(ratio, 0), 1))
+	return true
+}
+
+// spanKinds are the span kinds of the sampling rules, by name.
+var spanKinds = map[string]trace.SpanKind{
+	"internal": trace.SpanKindInternal,
+	"server":   trace.SpanKindServer,
+	"client":   trace.SpanKindClient,
+	"producer": trace.SpanKindProducer,
+	"consumer": trace.SpanKindConsumer,
+}
+
+// samplingRule decides the sampling of the spans which match all of its conditions.
+type samplingRule struct {
+	name       string
+	prefix     bool
+	kind       trace.SpanKind
+	attributes map[attribute.Key]string
+	sampler    sdktrace.Sampler
+}
+
+// newSamplingRule converts a sampling rule of the configuration.
+func newSamplingRule(rule config.SamplingRule) samplingRule {
+	r := samplingRule{
+		kind:       spanKinds[rule.Kind],
+		attributes: make(map[attribute.Key]string, len(rule.Attributes)),
+		sampler:    sdktrace.TraceIDRatioBased(rule.Ratio),
+	}
+	r.name, r.prefix = strings.CutSuffix(rule.Name, "*")
+	for k, v := range rule.Attributes {
+		r.attributes[attribute.Key(k)] = v
+	}
+
+	return r
+}
+
+// matches returns whether the span matches all the conditions of the rule.
+func (r samplingRule) matches(p sdktrace.SamplingParameters) bool {
+	if r.prefix && !strings.HasPrefix(p.Name, r.name) || !r.prefix && r.name != "" && p.Name != r.name {
+		return false
+	}
+	if r.kind != trace.SpanKindUnspecified && p.Kind != r.kind {
+		return false
+	}
+
+	for key, value := range r.attributes {
+		found := false
+		for _, attr := range p.Attributes {
+			if attr.Key == key && attr.Value.Emit() == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ruleSampler decides the sampling of the entry spans of the process (the root spans and the spans
+// with a remote parent) with the first matching rule, and delegates any other span to the fallback sampler.
+//
+// The spans with a local parent are always delegated, so the traces stay complete within the process.
+type ruleSampler struct {
+	rules    []samplingRule
+	fallback sdktrace.Sampler
+}
+
+// ShouldSample returns the decision of the first matching rule, or of the fallback sampler.
+func (s ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := trace.SpanContextFromContext(p.ParentContext)
+	if !parent.IsValid() || parent.IsRemote() {
+		for _, rule := range s.rules {
+			if rule.matches(p) {
+				return rule.sampler.ShouldSample(p)
+			}
+		}
+	}
+
+	return s.fallback.ShouldSample(p)
+}
+
+// Description returns the description of the sampler.
+func (s ruleSampler) Description() string {
+	return fmt.Sprintf("RuleBased{rules:%d,fallback:%s}", len(s.rules), s.fallback.Description())
+}
+
+// newSampler returns the sampler of a tracer provider, as configured in `OTEL_TRACES_SAMPLER`,
+// `OTEL_TRACES_SAMPLER_ARG` and `OTEL_TRACES_SAMPLER_RULES`.
+//
+// It also returns the ratio sampler of which the ratio can be changed, or nil if the sampler is not ratio based.
+func newSampler(cfg config.MonitoringConfig) (sdktrace.Sampler, *ratioSampler, error) {
+	name, err := cfg.TracesSampler()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ratio, err := cfg.TracesSamplingRatio()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sampler sdktrace.Sampler
+	var ratioBased *ratioSampler
+	switch name {
+	case config.SamplerAlwaysOn:
+		sampler = sdktrace.AlwaysSample()
+	case config.SamplerAlwaysOff:
+		sampler = sdktrace.NeverSample()
+	case config.SamplerTraceIDRatio:
+		ratioBased = newRatioSampler(min(max(ratio, 0), 1))
+		sampler = ratioBased
+	case config.SamplerParentBasedAlwaysOn:
+		sampler = sdktrace.ParentBased(sdktrace.AlwaysSample())
+	case config.SamplerParentBasedAlwaysOff:
+		sampler = sdktrace.ParentBased(sdktrace.NeverSample())
+	default:
+		ratioBased = newRatioSampler(min(max(ratio, 0), 1))
+		sampler = sdktrace.ParentBased(ratioBased)
+	}
+
+	rules, err := cfg.TracesSamplingRules()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rules) == 0 {
+		return sampler, ratioBased, nil
+	}
+
+	ruleSampler := ruleSampler{
+		rules:    make([]samplingRule, 0, len(rules)),
+		fallback: sampler,
+	}
+	for _, rule := range rules {
+		ruleSampler.rules = append(ruleSampler.rules, newSamplingRule(rule))
+	}
+
+	return ruleSampler, ratioBased, nil
+}
@@ -0,0 +1,240 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestNewSampler(t *testing.T) {
+	tests := []struct {
+		name                string
+		sampler             string
+		ratio               string
+		expectedDescription string
+		expectedRatio       bool
+	}{
+		{
+			name:                "with default sampler",
+			expectedDescription: "ParentBased{root:ReloadableTraceIDRatioBased{1}",
+			expectedRatio:       true,
+		},
+		{
+			name:                "with always on sampler",
+			sampler:             "always_on",
+			expectedDescription: "AlwaysOnSampler",
+		},
+		{
+			name:                "with always off sampler",
+			sampler:             "always_off",
+			expectedDescription: "AlwaysOffSampler",
+		},
+		{
+			name:                "with trace id ratio sampler",
+			sampler:             "traceidratio",
+			ratio:               "0.5",
+			expectedDescription: "ReloadableTraceIDRatioBased{0.5}",
+			expectedRatio:       true,
+		},
+		{
+			name:                "with parent based always off sampler",
+			sampler:             "parentbased_always_off",
+			expectedDescription: "ParentBased{root:AlwaysOffSampler",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := getMonitoringConfig()
+			cfg.TracesSamplerCfg = tt.sampler
+			cfg.TracesSamplerArgCfg = tt.ratio
+
+			sampler, ratio, err := newSampler(cfg)
+			require.NoError(t, err)
+			assert.Contains(t, sampler.Description(), tt.expectedDescription)
+			assert.Equal(t, tt.expectedRatio, ratio != nil)
+		})
+	}
+
+	t.Run("with rules", func(t *testing.T) {
+		cfg := getMonitoringConfig()
+		cfg.TracesSamplerCfg = "always_off"
+		cfg.TracesSamplingRulesCfg = "always_on:name=POST /checkout"
+
+		sampler, _, err := newSampler(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, "RuleBased{rules:1,fallback:AlwaysOffSampler}", sampler.Description())
+	})
+
+	t.Run("with invalid sampler", func(t *testing.T) {
+		cfg := getMonitoringConfig()
+		cfg.TracesSamplerCfg = "sometimes"
+
+		_, _, err := newSampler(cfg)
+		require.ErrorIs(t, err, config.ErrInvalidSamplerConfig)
+	})
+
+	t.Run("with invalid rules", func(t *testing.T) {
+		cfg := getMonitoringConfig()
+		cfg.TracesSamplingRulesCfg = "always_on"
+
+		_, _, err := newSampler(cfg)
+		require.ErrorIs(t, err, config.ErrInvalidSamplerConfig)
+	})
+}
+
+func TestRuleSampler(t *testing.T) {
+	cfg := getMonitoringConfig()
+	cfg.TracesSamplerCfg = "parentbased_always_off"
+	cfg.TracesSamplingRulesCfg = "always_off:attr.http.route=/healthz;always_on:name=POST /checkout*;always_on:kind=consumer"
+
+	sampler, _, err := newSampler(cfg)
+	require.NoError(t, err)
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler)).Tracer("test")
+
+	tests := []struct {
+		name     string
+		span     string
+		options  []trace.SpanStartOption
+		expected bool
+	}{
+		{
+			name:     "matches the name prefix",
+			span:     "POST /checkout/confirm",
+			expected: true,
+		},
+		{
+			name:     "matches the kind",
+			span:     "process order",
+			options:  []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindConsumer)},
+			expected: true,
+		},
+		{
+			name:     "first matching rule wins",
+			span:     "POST /checkout",
+			options:  []trace.SpanStartOption{trace.WithAttributes(attribute.String("http.route", "/healthz"))},
+			expected: false,
+		},
+		{
+			name:     "falls back without matching rule",
+			span:     "GET /orders",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, span := tracer.Start(context.Background(), tt.span, tt.options...)
+			defer span.End()
+
+			assert.Equal(t, tt.expected, span.SpanContext().IsSampled())
+		})
+	}
+
+	t.Run("local children follow the parent", func(t *testing.T) {
+		ctx, parent := tracer.Start(context.Background(), "GET /orders")
+		defer parent.End()
+
+		_, child := tracer.Start(ctx, "POST /checkout")
+		defer child.End()
+
+		assert.False(t, child.SpanContext().IsSampled())
+	})
+
+	t.Run("remote children use the rules", func(t *testing.T) {
+		remote := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{1},
+			Remote:  true,
+		})
+		ctx := trace.ContextWithRemoteSpanContext(context.Background(), remote)
+
+		_, span := tracer.Start(ctx, "POST /checkout")
+		defer span.End()
+
+		assert.True(t, span.SpanContext().IsSampled())
+	})
+}
+
+func TestSetSamplingRatio(t *testing.T) {
+	ctx := context.Background()
+	t.Cleanup(func() { StarCustomTracer(noop.Tracer{}) })
+
+	tests := []struct {
+		name     string
+		sampler  string
+		expected bool
+	}{
+		{
+			name:     "with trace id ratio sampler",
+			sampler:  "traceidratio",
+			expected: true,
+		},
+		{
+			name:     "with parent based trace id ratio sampler",
+			sampler:  "parentbased_traceidratio",
+			expected: true,
+		},
+		{
+			name:    "with always on sampler",
+			sampler: "always_on",
+		},
+		{
+			name:    "with parent based always off sampler",
+			sampler: "parentbased_always_off",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+				"OTEL_SERVICE_NAME":       "test-service",
+				"OTEL_EXPORTER_OTLP_TEST": "true",
+				"OTEL_TRACES_SAMPLER":     tt.sampler,
+			}))
+			require.NoError(t, err)
+			require.NoError(t, StartDefaultTracer(ctx, WithConfig(cfg)))
+			defer func() { require.NoError(t, ShutdownTracerProvider(ctx)) }()
+
+			assert.Equal(t, tt.expected, SetSamplingRatio(0.25))
+			if tt.expected {
+				assert.Equal(t, 0.25, *defaultTracer.provider.samplingRatio.ratio.Load())
+			}
+		})
+	}
+
+	t.Run("without default tracer", func(t *testing.T) {
+		StarCustomTracer(noop.Tracer{})
+
+		assert.False(t, SetSamplingRatio(0.25))
+	})
+}
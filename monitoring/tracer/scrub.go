@@ -0,0 +1,208 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// redactedValue is the value of the redacted attributes.
+const redactedValue = "[REDACTED]"
+
+// scrubRule is a compiled scrubbing rule. A nil condition matches any attribute.
+type scrubRule struct {
+	action  string
+	key     *regexp.Regexp
+	value   *regexp.Regexp
+	hashKey []byte
+}
+
+// scrubProcessor redacts, hashes or drops the span and event attributes which match the scrubbing rules,
+// and passes the scrubbed spans to the next processors.
+//
+// The first matching rule of an attribute wins.
+type scrubProcessor struct {
+	next  []sdktrace.SpanProcessor
+	rules []scrubRule
+}
+
+// scrubbedSpan is a span with scrubbed attributes and events.
+type scrubbedSpan struct {
+	sdktrace.ReadOnlySpan
+	attributes []attribute.KeyValue
+	events     []sdktrace.Event
+}
+
+// Attributes returns the scrubbed attributes of the span.
+func (s scrubbedSpan) Attributes() []attribute.KeyValue {
+	return s.attributes
+}
+
+// Events returns the events of the span, with scrubbed attributes.
+func (s scrubbedSpan) Events() []sdktrace.Event {
+	return s.events
+}
+
+// newScrubProcessor returns a scrubProcessor which applies the rules, and passes the spans to the next processors.
+func newScrubProcessor(rules []config.ScrubRule, next []sdktrace.SpanProcessor) *scrubProcessor {
+	p := &scrubProcessor{next: next, rules: make([]scrubRule, 0, len(rules))}
+	for _, r := range rules {
+		rule := scrubRule{action: r.Action, value: r.Value, hashKey: r.HashKey}
+		if r.Key != "" {
+			rule.key = keyPattern(r.Key)
+		}
+		p.rules = append(p.rules, rule)
+	}
+
+	return p
+}
+
+// keyPattern compiles the pattern of an attribute key, where a "*" matches any sequence of characters.
+func keyPattern(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// OnStart passes the started span to the next processors.
+func (p *scrubProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	for _, next := range p.next {
+		next.OnStart(parent, s)
+	}
+}
+
+// OnEnd scrubs the attributes of the ended span and of its events, and passes it to the next processors.
+func (p *scrubProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	attributes, scrubbed := p.scrub(s.Attributes())
+
+	events := s.Events()
+	var scrubbedEvents []sdktrace.Event
+	for i, event := range events {
+		eventAttributes, ok := p.scrub(event.Attributes)
+		if !ok {
+			continue
+		}
+		if scrubbedEvents == nil {
+			scrubbedEvents = append([]sdktrace.Event(nil), events...)
+		}
+		scrubbedEvents[i].Attributes = eventAttributes
+	}
+
+	if scrubbedEvents != nil {
+		events, scrubbed = scrubbedEvents, true
+	}
+	if scrubbed {
+		s = scrubbedSpan{ReadOnlySpan: s, attributes: attributes, events: events}
+	}
+
+	for _, next := range p.next {
+		next.OnEnd(s)
+	}
+}
+
+// scrub returns the scrubbed attributes, and whether any of them is scrubbed.
+// The attributes are only copied if any of them is scrubbed.
+func (p *scrubProcessor) scrub(attributes []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	var scrubbed []attribute.KeyValue
+
+	for i, attr := range attributes {
+		kv, ok := p.scrubAttribute(attr)
+		if !ok {
+			if scrubbed != nil {
+				scrubbed = append(scrubbed, attr)
+			}
+			continue
+		}
+
+		if scrubbed == nil {
+			scrubbed = make([]attribute.KeyValue, i, len(attributes))
+			copy(scrubbed, attributes[:i])
+		}
+		// a dropped attribute is not valid
+		if kv.Valid() {
+			scrubbed = append(scrubbed, kv)
+		}
+	}
+
+	if scrubbed == nil {
+		return attributes, false
+	}
+	return scrubbed, true
+}
+
+// scrubAttribute applies the first rule which matches the attribute, and returns whether any rule matches.
+// The returned attribute is not valid if it is dropped.
+func (p *scrubProcessor) scrubAttribute(attr attribute.KeyValue) (attribute.KeyValue, bool) {
+	for _, rule := range p.rules {
+		if rule.key != nil && !rule.key.MatchString(string(attr.Key)) {
+			continue
+		}
+		if rule.value != nil && !rule.value.MatchString(attr.Value.Emit()) {
+			continue
+		}
+
+		switch rule.action {
+		case config.ScrubDrop:
+			return attribute.KeyValue{}, true
+		case config.ScrubHash:
+			mac := hmac.New(sha256.New, rule.hashKey)
+			mac.Write([]byte(attr.Value.Emit()))
+			return attribute.String(string(attr.Key), hex.EncodeToString(mac.Sum(nil))), true
+		default:
+			return attribute.String(string(attr.Key), redactedValue), true
+		}
+	}
+
+	return attr, false
+}
+
+// ForceFlush flushes the next processors.
+func (p *scrubProcessor) ForceFlush(ctx context.Context) error {
+	var err error
+	for _, next := range p.next {
+		err = errors.Join(err, next.ForceFlush(ctx))
+	}
+	return err
+}
+
+// Shutdown shuts down the next processors.
+func (p *scrubProcessor) Shutdown(ctx context.Context) error {
+	var err error
+	for _, next := range p.next {
+		err = errors.Join(err, next.Shutdown(ctx))
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"testing"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestScrubProcessor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	processor := newScrubProcessor([]config.ScrubRule{
+		{Action: config.ScrubHash, Key: "user.id", HashKey: []byte("secret")},
+		{Action: config.ScrubDrop, Key: "passenger.*"},
+		{Action: config.ScrubRedact, Key: "*.email"},
+		{Action: config.ScrubRedact, Value: regexp.MustCompile(`[^@\s]+@[^@\s]+`)},
+	}, []sdktrace.SpanProcessor{sdktrace.NewSimpleSpanProcessor(exporter)})
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor))
+	defer func() {
+		require.NoError(t, tp.Shutdown(context.Background()))
+	}()
+
+	_, span := tp.Tracer("test").Start(context.Background(), "test-span")
+	span.SetAttributes(
+		attribute.String("user.id", "42"),
+		attribute.String("passenger.name", "Jane Doe"),
+		attribute.String("contact.email", "jane@example.com"),
+		attribute.String("message", "sent to jane@example.com"),
+		attribute.Int("seats", 2),
+	)
+	span.AddEvent("booking", trace.WithAttributes(
+		attribute.String("passenger.surname", "Doe"),
+		attribute.String("booking.id", "ABC123"),
+	))
+	span.AddEvent("checkout", trace.WithAttributes(attribute.String("booking.id", "ABC123")))
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("42"))
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("user.id", hex.EncodeToString(mac.Sum(nil))),
+		attribute.String("contact.email", redactedValue),
+		attribute.String("message", redactedValue),
+		attribute.Int("seats", 2),
+	}, spans[0].Attributes)
+
+	require.Len(t, spans[0].Events, 2)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("booking.id", "ABC123")}, spans[0].Events[0].Attributes)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("booking.id", "ABC123")}, spans[0].Events[1].Attributes)
+}
+
+func TestScrubProcessorWithoutMatch(t *testing.T) {
+	processor := newScrubProcessor([]config.ScrubRule{{Action: config.ScrubDrop, Key: "*.email"}}, nil)
+
+	attributes := []attribute.KeyValue{attribute.String("http.route", "/orders"), attribute.String("email.domain", "example.com")}
+	scrubbed, ok := processor.scrub(attributes)
+	assert.False(t, ok)
+	assert.Equal(t, attributes, scrubbed)
+}
@@ -0,0 +1,91 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	"time"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Link is a link from a span to another span (e.g. the span of the message which is processed)
+type Link = oteltrace.Link
+
+// spanStartOptions are the options of StartSpan
+type spanStartOptions struct {
+	otel          []oteltrace.SpanStartOption
+	withoutCaller bool
+}
+
+// SpanStartOption configures the span started by StartSpan
+type SpanStartOption func(*spanStartOptions)
+
+// WithAttributes adds the attributes to the span when it is started,
+// so they are also available to the sampler.
+func WithAttributes(attributes ...KeyValue) SpanStartOption {
+	return func(o *spanStartOptions) {
+		o.otel = append(o.otel, oteltrace.WithAttributes(attributes...))
+	}
+}
+
+// WithLinks links the span to the given spans, e.g. the spans of the messages processed in a batch.
+func WithLinks(links ...Link) SpanStartOption {
+	return func(o *spanStartOptions) {
+		o.otel = append(o.otel, oteltrace.WithLinks(links...))
+	}
+}
+
+// WithTimestamp sets the start time of the span, instead of the current time.
+func WithTimestamp(timestamp time.Time) SpanStartOption {
+	return func(o *spanStartOptions) {
+		o.otel = append(o.otel, oteltrace.WithTimestamp(timestamp))
+	}
+}
+
+// WithNewRoot starts a new trace, ignoring the span of the context.
+// The span of the context can still be linked with WithLinks.
+func WithNewRoot() SpanStartOption {
+	return func(o *spanStartOptions) {
+		o.otel = append(o.otel, oteltrace.WithNewRoot())
+	}
+}
+
+// WithoutCaller skips the caller attributes (the file, line, function and package which started the span),
+// e.g. when the span is started by a helper, whose location is not meaningful.
+func WithoutCaller() SpanStartOption {
+	return func(o *spanStartOptions) {
+		o.withoutCaller = true
+	}
+}
+
+// newSpanStartOptions returns the options of StartSpan.
+func newSpanStartOptions(kind SpanKind, opts []SpanStartOption) spanStartOptions {
+	o := spanStartOptions{
+		otel: []oteltrace.SpanStartOption{oteltrace.WithSpanKind(kind)},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
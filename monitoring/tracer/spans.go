@@ -36,14 +36,14 @@ type SpanKind = oteltrace.SpanKind
 type Code = codes.Code
 type KeyValue = attribute.KeyValue
 
-// StartSpan starts a new span with the given name and kind
+// StartSpan starts a new span with the given name, kind and start options (see SpanStartOption)
 //
 // It is using the default tracer. To start a new default tracer, first the function
 // tracer.StartDefaultTracer(...) should be called.
 //
 // It returns the new context and the new span
-func StartSpan(ctx context.Context, name string, kind SpanKind) (context.Context, span.Span) {
-	return defaultTracer.StartSpan(ctx, name, kind)
+func StartSpan(ctx context.Context, name string, kind SpanKind, opts ...SpanStartOption) (context.Context, span.Span) {
+	return defaultTracer.StartSpan(ctx, name, kind, opts...)
 }
 
 // GetSpanFromContext retrieves the current Span from the context.
@@ -0,0 +1,387 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tailSamplingTracesCounterName is the name of the counter incremented for every decision of the tail sampling
+	tailSamplingTracesCounterName = "traces.tail_sampling.traces"
+	// tailSamplingDroppedSpansCounterName is the name of the counter incremented for every span dropped by the tail sampling
+	tailSamplingDroppedSpansCounterName = "traces.tail_sampling.spans.dropped"
+	// tailSamplingForcedCounterName is the name of the counter incremented for every trace decided before it is complete
+	tailSamplingForcedCounterName = "traces.tail_sampling.traces.forced"
+	// tailSamplingEvictedCounterName is the name of the counter incremented for every decision forgotten before it expires
+	tailSamplingEvictedCounterName = "traces.tail_sampling.decisions.evicted"
+	// maxTailSamplingTick is the maximum interval at which the expired traces are decided
+	maxTailSamplingTick = time.Second
+)
+
+// the policies of the tail sampling, and the reasons of the early decisions
+const (
+	policyError     = "error"
+	policyLatency   = "latency"
+	policyAttribute = "attribute"
+	policyRatio     = "ratio"
+
+	reasonDecisionWait = "decision_wait"
+	reasonMaxTraces    = "max_traces"
+	reasonMaxSpans     = "max_spans"
+	reasonFlush        = "flush"
+)
+
+// bufferedTrace holds the ended spans of a trace until the decision.
+type bufferedTrace struct {
+	id       trace.TraceID
+	spans    []sdktrace.ReadOnlySpan
+	deadline time.Time
+	element  *list.Element
+}
+
+// tailDecision is the decision of a trace, kept for the spans which end after the decision.
+type tailDecision struct {
+	id      trace.TraceID
+	sampled bool
+	expires time.Time
+	element *list.Element
+}
+
+// tailSamplingProcessor buffers the spans per trace, and passes the spans of the sampled traces to the
+// next processors once the trace is complete, i.e. when its entry span in the process (the local root) ends.
+//
+// A trace is sampled if any of its spans has an error status, is longer than the latency threshold or has any of
+// the configured attributes. Otherwise, a ratio of the traces is sampled, based on the trace id.
+// A trace which is not complete within the decision wait, or which does not fit in the memory limits
+// (the oldest traces are decided first), is decided with the spans ended so far.
+type tailSamplingProcessor struct {
+	next       []sdktrace.SpanProcessor
+	cfg        config.TailSampling
+	attributes map[attribute.Key]string
+	ratio      sdktrace.Sampler
+
+	mu        sync.Mutex
+	traces    map[trace.TraceID]*bufferedTrace
+	order     *list.List
+	spans     int
+	decisions map[trace.TraceID]*tailDecision
+	// decisionOrder holds the decisions in the order of their expiration
+	decisionOrder *list.List
+
+	decided metric.Int64Counter
+	dropped metric.Int64Counter
+	forced  metric.Int64Counter
+	evicted metric.Int64Counter
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// newTailSamplingProcessor returns a tailSamplingProcessor which passes the sampled spans to the next processors,
+// and reports its metrics to the given MeterProvider.
+func newTailSamplingProcessor(cfg config.TailSampling, next []sdktrace.SpanProcessor, mp metric.MeterProvider) (*tailSamplingProcessor, error) {
+	p := &tailSamplingProcessor{
+		next:          next,
+		cfg:           cfg,
+		attributes:    make(map[attribute.Key]string, len(cfg.Attributes)),
+		ratio:         sdktrace.TraceIDRatioBased(cfg.Ratio),
+		traces:        map[trace.TraceID]*bufferedTrace{},
+		order:         list.New(),
+		decisions:     map[trace.TraceID]*tailDecision{},
+		decisionOrder: list.New(),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	for k, v := range cfg.Attributes {
+		p.attributes[attribute.Key(k)] = v
+	}
+
+	meter := mp.Meter(instrumentationName, metric.WithInstrumentationVersion(version.Version()))
+
+	var err error
+	p.decided, err = meter.Int64Counter(
+		tailSamplingTracesCounterName,
+		metric.WithDescription("The number of traces decided by the tail sampling, per decision and policy."),
+		metric.WithUnit("{trace}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.dropped, err = meter.Int64Counter(
+		tailSamplingDroppedSpansCounterName,
+		metric.WithDescription("The number of spans dropped by the tail sampling."),
+		metric.WithUnit("{span}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.forced, err = meter.Int64Counter(
+		tailSamplingForcedCounterName,
+		metric.WithDescription("The number of traces decided by the tail sampling before they are complete, per reason."),
+		metric.WithUnit("{trace}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.evicted, err = meter.Int64Counter(
+		tailSamplingEvictedCounterName,
+		metric.WithDescription("The number of decisions of the tail sampling forgotten before they expire, to make room for newer ones."),
+		metric.WithUnit("{trace}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	go p.run(min(cfg.DecisionWait, maxTailSamplingTick))
+
+	return p, nil
+}
+
+// run decides the expired traces at every tick, until the processor is shut down.
+func (p *tailSamplingProcessor) run(tick time.Duration) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			p.expire(now)
+		}
+	}
+}
+
+// OnStart passes the started span to the next processors.
+func (p *tailSamplingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	for _, next := range p.next {
+		next.OnStart(parent, s)
+	}
+}
+
+// OnEnd buffers the ended span until the decision of its trace.
+func (p *tailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+
+	id := s.SpanContext().TraceID()
+	now := time.Now()
+
+	p.mu.Lock()
+	if decision, ok := p.decisions[id]; ok {
+		p.mu.Unlock()
+
+		if decision.sampled {
+			p.forward([]sdktrace.ReadOnlySpan{s})
+		} else {
+			p.dropped.Add(context.Background(), 1)
+		}
+		return
+	}
+
+	t, ok := p.traces[id]
+	if !ok {
+		t = &bufferedTrace{id: id, deadline: now.Add(p.cfg.DecisionWait)}
+		t.element = p.order.PushBack(t)
+		p.traces[id] = t
+	}
+	t.spans = append(t.spans, s)
+	p.spans++
+
+	var sampled []sdktrace.ReadOnlySpan
+	if parent := s.Parent(); !parent.IsValid() || parent.IsRemote() {
+		sampled = append(sampled, p.decide(t, now)...)
+	}
+	for len(p.traces) > p.cfg.MaxTraces || p.spans > p.cfg.MaxSpans {
+		reason := reasonMaxSpans
+		if len(p.traces) > p.cfg.MaxTraces {
+			reason = reasonMaxTraces
+		}
+		p.forced.Add(context.Background(), 1, metric.WithAttributes(attribute.String("reason", reason)))
+		sampled = append(sampled, p.decide(p.order.Front().Value.(*bufferedTrace), now)...)
+	}
+	p.mu.Unlock()
+
+	p.forward(sampled)
+}
+
+// expire decides the traces which are buffered for longer than the decision wait,
+// and forgets the expired decisions.
+func (p *tailSamplingProcessor) expire(now time.Time) {
+	p.mu.Lock()
+
+	var sampled []sdktrace.ReadOnlySpan
+	// the traces are in the order of their deadlines
+	for e := p.order.Front(); e != nil && !e.Value.(*bufferedTrace).deadline.After(now); e = p.order.Front() {
+		p.forced.Add(context.Background(), 1, metric.WithAttributes(attribute.String("reason", reasonDecisionWait)))
+		sampled = append(sampled, p.decide(e.Value.(*bufferedTrace), now)...)
+	}
+
+	// the decisions are in the order of their expiration
+	for e := p.decisionOrder.Front(); e != nil && !e.Value.(*tailDecision).expires.After(now); e = p.decisionOrder.Front() {
+		p.forget(e.Value.(*tailDecision))
+	}
+	p.mu.Unlock()
+
+	p.forward(sampled)
+}
+
+// flush decides all the buffered traces.
+func (p *tailSamplingProcessor) flush() {
+	now := time.Now()
+	p.mu.Lock()
+
+	var sampled []sdktrace.ReadOnlySpan
+	for e := p.order.Front(); e != nil; e = p.order.Front() {
+		p.forced.Add(context.Background(), 1, metric.WithAttributes(attribute.String("reason", reasonFlush)))
+		sampled = append(sampled, p.decide(e.Value.(*bufferedTrace), now)...)
+	}
+	p.mu.Unlock()
+
+	p.forward(sampled)
+}
+
+// decide removes the trace from the buffer, and returns its spans if it is sampled.
+// The decision is kept for the spans which end later; when MaxTraces decisions are kept,
+// the oldest one is forgotten to make room for it.
+//
+// It must be called with the lock held.
+func (p *tailSamplingProcessor) decide(t *bufferedTrace, now time.Time) []sdktrace.ReadOnlySpan {
+	p.order.Remove(t.element)
+	delete(p.traces, t.id)
+	p.spans -= len(t.spans)
+
+	policy, sampled := p.policy(t)
+	for len(p.decisions) >= p.cfg.MaxTraces {
+		p.evicted.Add(context.Background(), 1)
+		p.forget(p.decisionOrder.Front().Value.(*tailDecision))
+	}
+	decision := &tailDecision{id: t.id, sampled: sampled, expires: now.Add(p.cfg.DecisionWait)}
+	decision.element = p.decisionOrder.PushBack(decision)
+	p.decisions[t.id] = decision
+
+	if !sampled {
+		p.decided.Add(context.Background(), 1, metric.WithAttributes(attribute.String("decision", "dropped")))
+		p.dropped.Add(context.Background(), int64(len(t.spans)))
+		return nil
+	}
+
+	p.decided.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("decision", "sampled"),
+		attribute.String("policy", policy),
+	))
+	return t.spans
+}
+
+// forget removes the decision of a trace.
+//
+// It must be called with the lock held.
+func (p *tailSamplingProcessor) forget(decision *tailDecision) {
+	p.decisionOrder.Remove(decision.element)
+	delete(p.decisions, decision.id)
+}
+
+// policy returns the first policy which samples the trace, if any.
+func (p *tailSamplingProcessor) policy(t *bufferedTrace) (string, bool) {
+	for _, s := range t.spans {
+		if s.Status().Code == codes.Error {
+			return policyError, true
+		}
+	}
+
+	if p.cfg.LatencyThreshold > 0 {
+		for _, s := range t.spans {
+			if s.EndTime().Sub(s.StartTime()) >= p.cfg.LatencyThreshold {
+				return policyLatency, true
+			}
+		}
+	}
+
+	if len(p.attributes) > 0 {
+		for _, s := range t.spans {
+			for _, attr := range s.Attributes() {
+				if value, ok := p.attributes[attr.Key]; ok && attr.Value.Emit() == value {
+					return policyAttribute, true
+				}
+			}
+		}
+	}
+
+	if p.ratio.ShouldSample(sdktrace.SamplingParameters{TraceID: t.id}).Decision == sdktrace.RecordAndSample {
+		return policyRatio, true
+	}
+
+	return "", false
+}
+
+// forward passes the spans of the sampled traces to the next processors.
+func (p *tailSamplingProcessor) forward(spans []sdktrace.ReadOnlySpan) {
+	for _, next := range p.next {
+		for _, s := range spans {
+			next.OnEnd(s)
+		}
+	}
+}
+
+// ForceFlush decides all the buffered traces, and flushes the next processors.
+func (p *tailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	p.flush()
+
+	var err error
+	for _, next := range p.next {
+		err = errors.Join(err, next.ForceFlush(ctx))
+	}
+	return err
+}
+
+// Shutdown decides all the buffered traces, and shuts down the next processors.
+func (p *tailSamplingProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+	<-p.done
+	p.flush()
+
+	var err error
+	for _, next := range p.next {
+		err = errors.Join(err, next.Shutdown(ctx))
+	}
+	return err
+}
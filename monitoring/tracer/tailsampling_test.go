@@ -0,0 +1,259 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startTailSampling returns a tracer which passes its spans to a tail sampling processor with the given configuration,
+// the processor, the exporter of the sampled spans, and the reader of the metrics.
+func startTailSampling(t *testing.T, cfg config.TailSampling) (trace.Tracer, *tailSamplingProcessor, *tracetest.InMemoryExporter, sdkmetric.Reader) {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	exporter := tracetest.NewInMemoryExporter()
+	processor, err := newTailSamplingProcessor(cfg, []sdktrace.SpanProcessor{sdktrace.NewSimpleSpanProcessor(exporter)}, mp)
+	require.NoError(t, err)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor))
+	t.Cleanup(func() {
+		require.NoError(t, tp.Shutdown(context.Background()))
+	})
+
+	return tp.Tracer("test"), processor, exporter, reader
+}
+
+// tailSamplingConfig returns a configuration which samples none of the traces without matching any policy.
+func tailSamplingConfig() config.TailSampling {
+	return config.TailSampling{
+		Enabled:          true,
+		DecisionWait:     time.Hour,
+		LatencyThreshold: time.Second,
+		Attributes:       map[string]string{"debug": "true"},
+		Ratio:            0,
+		MaxTraces:        100,
+		MaxSpans:         1000,
+	}
+}
+
+func TestTailSamplingPolicies(t *testing.T) {
+	tests := []struct {
+		name            string
+		child           func(span trace.Span)
+		childOptions    []trace.SpanStartOption
+		ratio           float64
+		expectedSampled bool
+	}{
+		{
+			name:            "without matching policy",
+			expectedSampled: false,
+		},
+		{
+			name: "with error status",
+			child: func(span trace.Span) {
+				span.SetStatus(codes.Error, "failed")
+			},
+			expectedSampled: true,
+		},
+		{
+			name:            "with latency above the threshold",
+			childOptions:    []trace.SpanStartOption{trace.WithTimestamp(time.Now().Add(-2 * time.Second))},
+			expectedSampled: true,
+		},
+		{
+			name: "with matching attribute",
+			child: func(span trace.Span) {
+				span.SetAttributes(attribute.Bool("debug", true))
+			},
+			expectedSampled: true,
+		},
+		{
+			name: "with other attribute value",
+			child: func(span trace.Span) {
+				span.SetAttributes(attribute.Bool("debug", false))
+			},
+			expectedSampled: false,
+		},
+		{
+			name:            "with ratio",
+			ratio:           1,
+			expectedSampled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tailSamplingConfig()
+			cfg.Ratio = tt.ratio
+			tracer, _, exporter, _ := startTailSampling(t, cfg)
+
+			ctx, root := tracer.Start(context.Background(), "root")
+			_, child := tracer.Start(ctx, "child", tt.childOptions...)
+			if tt.child != nil {
+				tt.child(child)
+			}
+			child.End()
+			assert.Empty(t, exporter.GetSpans())
+
+			root.End()
+			if tt.expectedSampled {
+				assert.Len(t, exporter.GetSpans(), 2)
+			} else {
+				assert.Empty(t, exporter.GetSpans())
+			}
+		})
+	}
+}
+
+func TestTailSamplingLateSpans(t *testing.T) {
+	tracer, _, exporter, reader := startTailSampling(t, tailSamplingConfig())
+
+	// the root ends before its children, which follow the decision of the trace
+	ctx, root := tracer.Start(context.Background(), "root")
+	root.SetStatus(codes.Error, "failed")
+	_, child := tracer.Start(ctx, "child")
+	root.End()
+	child.End()
+	assert.Len(t, exporter.GetSpans(), 2)
+
+	ctx, root = tracer.Start(context.Background(), "root")
+	_, child = tracer.Start(ctx, "child")
+	root.End()
+	child.End()
+	assert.Len(t, exporter.GetSpans(), 2)
+	assert.Equal(t, int64(2), int64Metric(t, reader, tailSamplingDroppedSpansCounterName))
+}
+
+func TestTailSamplingDecisionWait(t *testing.T) {
+	tracer, processor, exporter, reader := startTailSampling(t, tailSamplingConfig())
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+
+	processor.expire(time.Now())
+	assert.Empty(t, exporter.GetSpans())
+
+	processor.expire(time.Now().Add(time.Hour))
+	assert.Len(t, exporter.GetSpans(), 1)
+	assert.Equal(t, int64(1), int64Metric(t, reader, tailSamplingForcedCounterName))
+
+	// the decision is kept for the spans which end later
+	root.End()
+	assert.Len(t, exporter.GetSpans(), 2)
+
+	processor.expire(time.Now().Add(2 * time.Hour))
+	assert.Empty(t, processor.decisions)
+}
+
+func TestTailSamplingMemoryLimits(t *testing.T) {
+	cfg := tailSamplingConfig()
+	cfg.MaxTraces = 1
+	tracer, processor, exporter, reader := startTailSampling(t, cfg)
+
+	ctx, first := tracer.Start(context.Background(), "first")
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+
+	ctx, second := tracer.Start(context.Background(), "second")
+	_, child = tracer.Start(ctx, "child")
+	child.End()
+
+	// the oldest trace is decided to make room for the new one
+	assert.Len(t, exporter.GetSpans(), 1)
+	assert.Len(t, processor.traces, 1)
+	assert.Equal(t, int64(1), int64Metric(t, reader, tailSamplingForcedCounterName))
+
+	first.End()
+	second.End()
+	assert.Len(t, exporter.GetSpans(), 2)
+	assert.Zero(t, processor.spans)
+}
+
+func TestTailSamplingDecisionEviction(t *testing.T) {
+	cfg := tailSamplingConfig()
+	cfg.MaxTraces = 2
+	tracer, processor, exporter, reader := startTailSampling(t, cfg)
+
+	// the children end after the decision of their traces
+	children := make([]trace.Span, 0, 3)
+	for range 3 {
+		ctx, root := tracer.Start(context.Background(), "root")
+		root.SetStatus(codes.Error, "failed")
+		_, child := tracer.Start(ctx, "child")
+		root.End()
+		children = append(children, child)
+	}
+
+	// the oldest decision is forgotten to make room for the newest one
+	assert.Len(t, processor.decisions, 2)
+	assert.Equal(t, int64(1), int64Metric(t, reader, tailSamplingEvictedCounterName))
+
+	children[1].End()
+	children[2].End()
+	assert.Len(t, exporter.GetSpans(), 5)
+}
+
+func TestTailSamplingShutdown(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	processor, err := newTailSamplingProcessor(tailSamplingConfig(), []sdktrace.SpanProcessor{sdktrace.NewSimpleSpanProcessor(blockingExporter{
+		InMemoryExporter: exporter,
+		release:          closedChannel(),
+	})}, sdkmetric.NewMeterProvider())
+	require.NoError(t, err)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor))
+	ctx, root := tp.Tracer("test").Start(context.Background(), "root")
+	defer root.End()
+	_, child := tp.Tracer("test").Start(ctx, "child")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+
+	require.NoError(t, tp.Shutdown(context.Background()))
+	assert.Len(t, exporter.GetSpans(), 1)
+	assert.Empty(t, processor.traces)
+}
+
+// closedChannel returns a closed channel.
+func closedChannel() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}
@@ -25,8 +25,13 @@ package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tra
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	internalCredentials "github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/diagnostics"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/fileexporter"
+	internalResource "github.com/FLYR-Open-Source/flyr-lib-go/internal/resource"
 	"go.opentelemetry.io/otel"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -34,10 +39,11 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
-	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // ErrTracerProviderNotInitialized is returned when the tracer provider is not initialized
@@ -54,6 +60,10 @@ var ErrExporterClientNotSupported = errors.New("exporter client not supported")
 //
 // If the test flag is enabled, it returns a new stdout exporter.
 //
+// The endpoint, headers, timeout, compression and TLS settings are read from the
+// `OTEL_EXPORTER_OTLP_*` environment variables and passed explicitly to the exporter.
+// An invalid combination of these settings is returned as an error.
+//
 // Valid values are:
 //
 // - grpc to use OTLP/gRPC
@@ -65,63 +75,249 @@ func getExporter(ctx context.Context, cfg config.MonitoringConfig) (sdktrace.Spa
 		return stdouttrace.New(stdouttrace.WithPrettyPrint())
 	}
 
+	exporterCfg, err := cfg.TracesExporter()
+	if err != nil {
+		return nil, err
+	}
+
 	switch cfg.ExporterTracesProtocol() {
 	case "grpc":
-		client := otlptracegrpc.NewClient()
+		client := otlptracegrpc.NewClient(grpcOptions(exporterCfg)...)
 		return otlptrace.New(ctx, client)
 	case "http/protobuf":
-		client := otlptracehttp.NewClient()
+		client := otlptracehttp.NewClient(httpOptions(exporterCfg)...)
 		return otlptrace.New(ctx, client)
 	default:
 		return nil, ErrExporterClientNotSupported
 	}
 }
 
+// getExporters returns the exporters of the types given in `OTEL_TRACES_EXPORTER`.
+//
+// If the exporters are disabled (with `OTEL_SDK_DISABLED` or `OTEL_TRACES_EXPORTER=none`), it returns no exporters.
+func getExporters(ctx context.Context, cfg config.MonitoringConfig) ([]sdktrace.SpanExporter, error) {
+	types, err := cfg.TracesExporterTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	exporters := make([]sdktrace.SpanExporter, 0, len(types))
+	for _, t := range types {
+		var exporter sdktrace.SpanExporter
+
+		switch t {
+		case config.ExporterNone:
+			return nil, nil
+		case config.ExporterConsole:
+			exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+		case config.ExporterFile:
+			exporter, err = getFileExporter(ctx, cfg)
+		default:
+			exporter, err = getExporter(ctx, cfg)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		exporters = append(exporters, exporter)
+	}
+
+	return exporters, nil
+}
+
+// getFileExporter returns an exporter which writes the spans as OTLP JSON lines to the file given in `OTEL_EXPORTER_FILE_TRACES_PATH`.
+func getFileExporter(ctx context.Context, cfg config.MonitoringConfig) (sdktrace.SpanExporter, error) {
+	fileCfg, err := cfg.TracesFileExporter()
+	if err != nil {
+		return nil, err
+	}
+
+	return fileexporter.NewSpanExporter(ctx, fileCfg)
+}
+
+// grpcOptions converts the exporter configuration to OTLP/gRPC client options.
+func grpcOptions(cfg config.OTLPExporter) []otlptracegrpc.Option {
+	var opts []otlptracegrpc.Option
+
+	if strings.Contains(cfg.Endpoint, "://") {
+		opts = append(opts, otlptracegrpc.WithEndpointURL(cfg.Endpoint))
+	} else if cfg.Endpoint != "" {
+		opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlptracegrpc.WithTimeout(cfg.Timeout))
+	}
+	if cfg.Compression != "" {
+		opts = append(opts, otlptracegrpc.WithCompressor(cfg.Compression))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else if cfg.TLS != nil {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(cfg.TLS)))
+	}
+	if cfg.HeaderProvider != nil {
+		perRPC := internalCredentials.NewPerRPCCredentials(cfg.HeaderProvider, !cfg.Insecure)
+		opts = append(opts, otlptracegrpc.WithDialOption(grpc.WithPerRPCCredentials(perRPC)))
+	}
+
+	return opts
+}
+
+// httpOptions converts the exporter configuration to OTLP/HTTP client options.
+func httpOptions(cfg config.OTLPExporter) []otlptracehttp.Option {
+	var opts []otlptracehttp.Option
+
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(cfg.Timeout))
+	}
+	if cfg.Compression == "gzip" {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	} else if cfg.TLS != nil {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(cfg.TLS))
+	}
+	if cfg.HeaderProvider != nil {
+		// the client replaces the TLS configuration and the timeout of the exporter
+		opts = append(opts, otlptracehttp.WithHTTPClient(internalCredentials.NewHTTPClient(cfg.HeaderProvider, cfg.TLS, cfg.Timeout)))
+	}
+
+	return opts
+}
+
+// tracerProvider is a TracerProvider with the state which can be changed while it is in use.
+type tracerProvider struct {
+	oteltrace.TracerProvider
+
+	// samplingRatio is the ratio sampler, or nil if the sampler is not ratio based (see SetSamplingRatio)
+	samplingRatio *ratioSampler
+	// viewer is the trace viewer, or nil if it is not enabled (see ViewerHandler)
+	viewer *viewerProcessor
+}
+
 // initializeTracerProvider creates and configures a new OpenTelemetry TracerProvider.
 //
 // This function initializes a TracerProvider with the specified configuration,
 // including a resource that describes the service, version, environment, and tenant.
-// This TracerProvider is also set as the global tracer provider for OpenTelemetry.
-//
-// Furthermore, it configures a composite text map propagator for trace context and baggage
-// propagation, which is essential for distributed tracing.
+// A noop TracerProvider is returned if the service name is not set or the spans are not exported.
 //
 // It returns an error if any occurred.
-func initializeTracerProvider(ctx context.Context, cfg config.MonitoringConfig) error {
+func initializeTracerProvider(ctx context.Context, cfg config.MonitoringConfig) (*tracerProvider, error) {
 	if cfg.Service() == "" {
-		otel.SetTracerProvider(noop.NewTracerProvider())
-		return nil
+		diagnostics.SetExporter(diagnostics.SignalTraces, config.ExporterNone, nil)
+		return &tracerProvider{TracerProvider: noop.NewTracerProvider()}, nil
+	}
+
+	exporters, err := getExporters(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sampler, samplingRatio, err := newSampler(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := cfg.TracesBatchProcessor()
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := cfg.TracesSpanLimits()
+	if err != nil {
+		return nil, err
 	}
 
-	exporter, err := getExporter(ctx, cfg)
+	tail, err := cfg.TracesTailSampling()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	scrubRules, err := cfg.TracesScrubRules()
+	if err != nil {
+		return nil, err
+	}
+
+	viewerSpans, err := cfg.TracesViewerSpans()
+	if err != nil {
+		return nil, err
+	}
+
+	// the trace viewer works without exporters
+	if len(exporters) == 0 && viewerSpans == 0 {
+		diagnostics.SetExporter(diagnostics.SignalTraces, config.ExporterNone, nil)
+		return &tracerProvider{TracerProvider: noop.NewTracerProvider()}, nil
 	}
 
-	resourceInfo, err := resource.New(
+	resourceInfo, err := internalResource.WithAttributes(
 		ctx,
-		resource.WithFromEnv(),
-		resource.WithTelemetrySDK(),
-		resource.WithContainer(),
-		resource.WithHost(),
-		resource.WithAttributes(
-			attribute.String(config.EXPORTER_PROTOCOL, cfg.ExporterTracesProtocol()),
-		),
+		cfg.Service(),
+		attribute.String(config.EXPORTER_PROTOCOL, cfg.ExporterTracesProtocol()),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+	types, _ := cfg.TracesExporterTypes()
+	diagnostics.SetExporter(diagnostics.SignalTraces, diagnostics.ExporterName(types, cfg.ExporterTracesProtocol()), resourceInfo)
+
+	opts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(resourceInfo),
-	)
-	otel.SetTracerProvider(tp)
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithRawSpanLimits(spanLimits(limits)),
+	}
+	processors := make([]sdktrace.SpanProcessor, 0, len(exporters))
+	for i, exporter := range exporters {
+		name := diagnostics.ExporterName(types[i:i+1], cfg.ExporterTracesProtocol())
+		processor, err := newBatchProcessor(diagnostics.WrapSpanExporter(exporter), name, batch, otel.GetMeterProvider())
+		if err != nil {
+			return nil, err
+		}
+		processors = append(processors, processor)
+	}
 
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{}, propagation.Baggage{}))
+	// the attributes are scrubbed right before the batch processors, so the tail sampling sees the original attributes
+	if len(scrubRules) > 0 && len(processors) > 0 {
+		processors = []sdktrace.SpanProcessor{newScrubProcessor(scrubRules, processors)}
+	}
+	// with the tail sampling, the spans of the sampled traces are passed to the batch processors once the trace is complete
+	if tail.Enabled && len(processors) > 0 {
+		processor, err := newTailSamplingProcessor(tail, processors, otel.GetMeterProvider())
+		if err != nil {
+			return nil, err
+		}
+		processors = []sdktrace.SpanProcessor{processor}
+	}
+	// the trace viewer keeps the scrubbed spans before the tail sampling
+	var v *viewerProcessor
+	if viewerSpans > 0 {
+		v = newViewerProcessor(viewerSpans)
+
+		if len(scrubRules) > 0 {
+			processors = append(processors, newScrubProcessor(scrubRules, []sdktrace.SpanProcessor{v}))
+		} else {
+			processors = append(processors, v)
+		}
+	}
+	for _, processor := range processors {
+		opts = append(opts, sdktrace.WithSpanProcessor(processor))
+	}
 
-	return nil
+	return &tracerProvider{
+		TracerProvider: sdktrace.NewTracerProvider(opts...),
+		samplingRatio:  samplingRatio,
+		viewer:         v,
+	}, nil
 }
 
 // ShutdownTracerProvider gracefully shuts down the global TracerProvider.
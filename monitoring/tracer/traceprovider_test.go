@@ -24,12 +24,17 @@ package tracer
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/credentials"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func getMonitoringConfig() config.Monitoring {
@@ -77,6 +82,61 @@ func TestGetExporter(t *testing.T) {
 		require.Nil(t, client)
 		require.ErrorIs(t, err, ErrExporterClientNotSupported)
 	})
+	t.Run("ReturnsExporterWithSettings", func(t *testing.T) {
+		cfg.ExporterProtocolCfg = "http/protobuf"
+		cfg.TestExporterCfg = false
+		cfg.ExporterCfg = config.ExporterSettings{
+			EndpointCfg:    "http://collector:4318",
+			HeadersCfg:     "api-key=secret",
+			TimeoutCfg:     "5000",
+			CompressionCfg: "gzip",
+		}
+		defer func() { cfg.ExporterCfg = config.ExporterSettings{} }()
+
+		client, err := getExporter(ctx, cfg)
+		require.NotNil(t, client)
+		require.NoError(t, err)
+	})
+
+	t.Run("ReturnsErrorForInvalidSettings", func(t *testing.T) {
+		cfg.ExporterProtocolCfg = "grpc"
+		cfg.TestExporterCfg = false
+		cfg.ExporterCfg = config.ExporterSettings{CompressionCfg: "zstd"}
+		defer func() { cfg.ExporterCfg = config.ExporterSettings{} }()
+
+		client, err := getExporter(ctx, cfg)
+		require.Nil(t, client)
+		require.ErrorIs(t, err, config.ErrInvalidExporterConfig)
+	})
+}
+
+func TestExporterHeaderProvider(t *testing.T) {
+	ctx := context.Background()
+
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Api-Key")
+	}))
+	t.Cleanup(server.Close)
+
+	cfg, err := config.ParseMonitoringConfig(
+		config.WithEnvironment(map[string]string{
+			"OTEL_EXPORTER_OTLP_PROTOCOL":        "http/protobuf",
+			"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": server.URL + "/v1/traces",
+		}),
+		config.WithHeaderProvider(credentials.HeaderProviderFunc(func(context.Context) (map[string]string, error) {
+			return map[string]string{"Api-Key": "refreshed"}, nil
+		})),
+	)
+	require.NoError(t, err)
+
+	exporter, err := getExporter(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = exporter.Shutdown(ctx) })
+
+	spans := tracetest.SpanStubs{{Name: "span"}}.Snapshots()
+	require.NoError(t, exporter.ExportSpans(ctx, spans))
+	require.Equal(t, "refreshed", <-received)
 }
 
 func TestNewTraceProvider(t *testing.T) {
@@ -84,23 +144,24 @@ func TestNewTraceProvider(t *testing.T) {
 	cfg := getMonitoringConfig()
 
 	t.Run("ReturnsNoError", func(t *testing.T) {
-		err := initializeTracerProvider(ctx, cfg)
+		_, err := initializeTracerProvider(ctx, cfg)
 		require.NoError(t, err)
 	})
 
 	t.Run("SetsCorrectResourceAttributes", func(t *testing.T) {
-		err := initializeTracerProvider(ctx, cfg)
+		tp, err := initializeTracerProvider(ctx, cfg)
 		require.NoError(t, err)
 
 		// Ensure tracerProvider is still usable
-		tracer := otel.GetTracerProvider().Tracer("test-tracer")
+		tracer := tp.Tracer("test-tracer")
 		_, span := tracer.Start(ctx, "test-span")
 		defer span.End()
 	})
 
 	t.Run("ConfiguresTextMapPropagator", func(t *testing.T) {
-		err := initializeTracerProvider(ctx, cfg)
+		err := StartDefaultTracer(ctx, WithConfig(cfg))
 		require.NoError(t, err)
+		defer func() { require.NoError(t, ShutdownTracerProvider(ctx)) }()
 
 		// Retrieve the global TextMapPropagator and confirm it’s a composite propagator.
 		propagator := otel.GetTextMapPropagator()
@@ -124,3 +185,91 @@ func TestNewTraceProvider(t *testing.T) {
 		require.NotNil(t, baggageInjected, "Baggage should be part of the TextMapPropagator")
 	})
 }
+
+func TestStartDefaultTracer_WithConfig(t *testing.T) {
+	ctx := context.Background()
+
+	cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+		"OTEL_SERVICE_NAME":       "explicit-service",
+		"OTEL_EXPORTER_OTLP_TEST": "true",
+	}))
+	require.NoError(t, err)
+
+	err = StartDefaultTracer(ctx, WithConfig(cfg))
+	require.NoError(t, err)
+	require.NotEqual(t, noop.Tracer{}, defaultTracer.tracer)
+
+	require.NoError(t, ShutdownTracerProvider(ctx))
+}
+
+func TestGetExporters(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		variables     map[string]string
+		expectedCount int
+		expectedError bool
+	}{
+		{
+			name:          "with default exporter",
+			variables:     map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc"},
+			expectedCount: 1,
+		},
+		{
+			name:          "with otlp and console exporters",
+			variables:     map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc", "OTEL_TRACES_EXPORTER": "otlp,console"},
+			expectedCount: 2,
+		},
+		{
+			name:          "with console exporter and no protocol",
+			variables:     map[string]string{"OTEL_TRACES_EXPORTER": "console"},
+			expectedCount: 1,
+		},
+		{
+			name:      "with none exporter",
+			variables: map[string]string{"OTEL_TRACES_EXPORTER": "none", "OTEL_METRICS_EXPORTER": "otlp"},
+		},
+		{
+			name:      "with sdk disabled",
+			variables: map[string]string{"OTEL_SDK_DISABLED": "true"},
+		},
+		{
+			name:          "with unsupported exporter",
+			variables:     map[string]string{"OTEL_TRACES_EXPORTER": "zipkin"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(tt.variables))
+			require.NoError(t, err)
+
+			exporters, err := getExporters(ctx, cfg)
+			if tt.expectedError {
+				require.ErrorIs(t, err, config.ErrInvalidExporterConfig)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, exporters, tt.expectedCount)
+		})
+	}
+}
+
+func TestNewTraceProvider_Disabled(t *testing.T) {
+	ctx := context.Background()
+
+	cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+		"OTEL_SERVICE_NAME":    "test-service",
+		"OTEL_TRACES_EXPORTER": "none",
+	}))
+	require.NoError(t, err)
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	require.NoError(t, StartDefaultTracer(ctx, WithConfig(cfg)))
+	require.IsType(t, noop.TracerProvider{}, otel.GetTracerProvider())
+	// the trace context is still propagated to the other services
+	require.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+}
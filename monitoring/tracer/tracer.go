@@ -29,20 +29,21 @@ import (
 	oteltrace "go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 
-	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/option"
 	internalSpan "github.com/FLYR-Open-Source/flyr-lib-go/internal/span"
 	internalUtils "github.com/FLYR-Open-Source/flyr-lib-go/internal/utils"
 	"github.com/FLYR-Open-Source/flyr-lib-go/internal/version"
 )
 
 const (
-	// The depth of the caller in the stack trace
-	callerDepth = 3
+	// The depth of the caller in the stack trace, from the function which starts the span
+	callerDepth = 4
 )
 
 // Tracer is a wrapper around the OpenTelemetry Tracer
 type Tracer struct {
-	tracer oteltrace.Tracer
+	tracer   oteltrace.Tracer
+	provider *tracerProvider
 }
 
 // defaultTracer is the default tracer used by the application.
@@ -61,23 +62,33 @@ var defaultTracer *Tracer = &Tracer{
 //
 // The function also sets the global default tracer to be used for tracing in the
 // application. If tracing is not enabled, it returns nil without starting a tracer.
+// Its TracerProvider is set as the global tracer provider for OpenTelemetry, together with the
+// text map propagator of the propagators given in `OTEL_PROPAGATORS` (trace context and baggage by default).
+//
+// The configuration is read from the environment, unless it is given with WithConfig.
 //
 // It returns an error if any occurred.
-func StartDefaultTracer(ctx context.Context) error {
-	cfg := config.NewMonitoringConfig()
+func StartDefaultTracer(ctx context.Context, opts ...Option) error {
+	cfg := option.MonitoringConfig(opts)
 
-	err := initializeTracerProvider(ctx, cfg)
+	// the trace context is propagated even if the spans of this service are not exported
+	propagators, err := cfg.Propagators()
 	if err != nil {
 		return err
 	}
+	otel.SetTextMapPropagator(newPropagator(propagators))
 
-	tc := otel.GetTracerProvider()
+	tp, err := initializeTracerProvider(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	otel.SetTracerProvider(tp.TracerProvider)
 
-	var tracer Tracer
+	tracer := Tracer{provider: tp}
 	if cfg.Service() == "" {
 		tracer.tracer = noop.Tracer{}
 	} else {
-		tracer.tracer = tc.Tracer(
+		tracer.tracer = tp.Tracer(
 			cfg.Service(),
 			oteltrace.WithInstrumentationVersion(version.Version()),
 		)
@@ -96,24 +107,33 @@ func StarCustomTracer(customTracer oteltrace.Tracer) {
 
 // StartSpan begins a new span for tracing with the specified name and kind.
 //
-// This method takes a context, a span name, and a span kind as arguments. It checks
-// if the Tracer instance is not nil, then starts a new span using the Tracer's Start
-// method. The caller's information is added to the span's attributes to provide
-// context about where the span was created. The function returns the updated context
-// and a Span object that wraps the created span.
+// This method takes a context, a span name, a span kind and optional start options as arguments
+// (see SpanStartOption). It checks if the Tracer instance is not nil, then starts a new span using
+// the Tracer's Start method. Unless WithoutCaller is given, the caller's information is added to the
+// span's attributes to provide context about where the span was created. The function returns the
+// updated context and a Span object that wraps the created span.
 //
 // It returns the new context and the Span.
-func (t *Tracer) StartSpan(parentCtx context.Context, name string, kind SpanKind) (context.Context, internalSpan.Span) {
+func (t *Tracer) StartSpan(parentCtx context.Context, name string, kind SpanKind, opts ...SpanStartOption) (context.Context, internalSpan.Span) {
+	return t.startSpan(parentCtx, name, kind, opts)
+}
+
+// startSpan begins a new span with the caller of the exported function which started it.
+//
+// All the exported functions must call it with the same number of frames in between (see callerDepth).
+func (t *Tracer) startSpan(parentCtx context.Context, name string, kind SpanKind, opts []SpanStartOption) (context.Context, internalSpan.Span) {
 	if t.tracer == nil {
 		return parentCtx, internalSpan.Span{}
 	}
 
-	ctxWithSpan, span := t.tracer.Start(parentCtx, name, oteltrace.WithSpanKind(kind))
+	o := newSpanStartOptions(kind, opts)
+	ctxWithSpan, span := t.tracer.Start(parentCtx, name, o.otel...)
 
 	// Add the caller to the span attributes
-	caller := internalUtils.GetCallerName(callerDepth)
-	attrs := caller.SpanAttributes()
-	span.SetAttributes(attrs...)
+	if !o.withoutCaller {
+		caller := internalUtils.GetCallerName(callerDepth)
+		span.SetAttributes(caller.SpanAttributes()...)
+	}
 
 	return ctxWithSpan, internalSpan.Span{Span: span}
 }
@@ -25,7 +25,9 @@ package tracer_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -65,6 +67,66 @@ func TestStartSpan(t *testing.T) {
 	assert.Equal(t, "value1", got["key1"])
 }
 
+func TestStartSpanOptions(t *testing.T) {
+	ctx := context.Background()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tracer.StarCustomTracer(tp.Tracer("test-tracer"))
+
+	//nolint:errcheck
+	defer tp.Shutdown(ctx)
+
+	parentCtx, parent := tracer.StartSpan(ctx, "parent", oteltrace.SpanKindServer)
+	parent.End()
+
+	t.Run("with attributes, links and timestamp", func(t *testing.T) {
+		sr.Reset()
+		start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		link := tracer.Link{SpanContext: parent.SpanContext()}
+
+		_, span := tracer.StartSpan(ctx, "test-span", oteltrace.SpanKindConsumer,
+			tracer.WithAttributes(attribute.String("key1", "value1")),
+			tracer.WithLinks(link),
+			tracer.WithTimestamp(start),
+		)
+		span.End()
+
+		ended := sr.Ended()
+		require.Len(t, ended, 1)
+		got := attrsToMap(ended[0].Attributes())
+		assert.Equal(t, oteltrace.SpanKindConsumer, ended[0].SpanKind())
+		assert.Equal(t, "value1", got["key1"])
+		assert.True(t, strings.HasSuffix(got["code.filepath"], "tracer_test.go"), "the caller must be the test, got %s", got["code.filepath"])
+		assert.Equal(t, start, ended[0].StartTime())
+		require.Len(t, ended[0].Links(), 1)
+		assert.Equal(t, parent.SpanContext(), ended[0].Links()[0].SpanContext)
+	})
+
+	t.Run("with new root", func(t *testing.T) {
+		sr.Reset()
+
+		_, span := tracer.StartSpan(parentCtx, "test-span", oteltrace.SpanKindInternal, tracer.WithNewRoot())
+		span.End()
+
+		ended := sr.Ended()
+		require.Len(t, ended, 1)
+		assert.False(t, ended[0].Parent().IsValid())
+		assert.NotEqual(t, parent.SpanContext().TraceID(), ended[0].SpanContext().TraceID())
+	})
+
+	t.Run("without caller", func(t *testing.T) {
+		sr.Reset()
+
+		_, span := tracer.StartSpan(ctx, "test-span", oteltrace.SpanKindInternal, tracer.WithoutCaller())
+		span.End()
+
+		ended := sr.Ended()
+		require.Len(t, ended, 1)
+		assert.Empty(t, ended[0].Attributes())
+	})
+}
+
 func attrsToMap(attrs []attribute.KeyValue) map[string]string {
 	m := make(map[string]string, len(attrs))
 	for _, kv := range attrs {
@@ -0,0 +1,145 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// maxViewerSpanNames is the maximum number of span names of which the viewer keeps the latency.
+const maxViewerSpanNames = 1000
+
+// latencyBounds are the upper bounds of the latency buckets of the span names.
+var latencyBounds = []time.Duration{
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+	100 * time.Second,
+}
+
+// spanNameStats is the number of spans of a name per latency bucket, and the number of failed spans.
+type spanNameStats struct {
+	name    string
+	buckets []int64
+	errors  int64
+}
+
+// viewerProcessor keeps the last ended spans and the latency of the span names in memory,
+// for the trace viewer (see ViewerHandler).
+type viewerProcessor struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+	next  int
+	full  bool
+	stats map[string]*spanNameStats
+}
+
+// newViewerProcessor returns a viewerProcessor which keeps the given number of spans.
+func newViewerProcessor(size int) *viewerProcessor {
+	return &viewerProcessor{
+		spans: make([]sdktrace.ReadOnlySpan, size),
+		stats: map[string]*spanNameStats{},
+	}
+}
+
+// OnStart does nothing.
+func (p *viewerProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd keeps the ended span, in place of the oldest one, and counts it in the latency of its name.
+func (p *viewerProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	latency := s.EndTime().Sub(s.StartTime())
+	bucket := sort.Search(len(latencyBounds), func(i int) bool {
+		return latency < latencyBounds[i]
+	})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.spans[p.next] = s
+	p.next = (p.next + 1) % len(p.spans)
+	p.full = p.full || p.next == 0
+
+	stats, ok := p.stats[s.Name()]
+	if !ok {
+		if len(p.stats) >= maxViewerSpanNames {
+			return
+		}
+		stats = &spanNameStats{name: s.Name(), buckets: make([]int64, len(latencyBounds)+1)}
+		p.stats[s.Name()] = stats
+	}
+	stats.buckets[bucket]++
+	if s.Status().Code == codes.Error {
+		stats.errors++
+	}
+}
+
+// recentSpans returns the kept spans, from the oldest to the newest.
+func (p *viewerProcessor) recentSpans() []sdktrace.ReadOnlySpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.full {
+		return append([]sdktrace.ReadOnlySpan(nil), p.spans[:p.next]...)
+	}
+	return append(append([]sdktrace.ReadOnlySpan(nil), p.spans[p.next:]...), p.spans[:p.next]...)
+}
+
+// spanNames returns a copy of the latency of the span names, sorted by name.
+func (p *viewerProcessor) spanNames() []spanNameStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make([]spanNameStats, 0, len(p.stats))
+	for _, stats := range p.stats {
+		names = append(names, spanNameStats{
+			name:    stats.name,
+			buckets: append([]int64(nil), stats.buckets...),
+			errors:  stats.errors,
+		})
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].name < names[j].name
+	})
+
+	return names
+}
+
+// ForceFlush does nothing, as the spans are kept in memory.
+func (p *viewerProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown does nothing, so the spans can still be viewed after the shutdown.
+func (p *viewerProcessor) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,151 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FLYR-Open-Source/flyr-lib-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestViewerProcessor(t *testing.T) {
+	processor := newViewerProcessor(2)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor))
+	tracer := tp.Tracer("test")
+
+	now := time.Now()
+	for _, name := range []string{"first", "second", "third"} {
+		_, span := tracer.Start(context.Background(), name, trace.WithTimestamp(now.Add(-5*time.Millisecond)))
+		span.End(trace.WithTimestamp(now))
+	}
+	_, span := tracer.Start(context.Background(), "third", trace.WithTimestamp(now.Add(-2*time.Second)))
+	span.SetStatus(codes.Error, "failed")
+	span.End(trace.WithTimestamp(now))
+
+	spans := processor.recentSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "third", spans[0].Name())
+	assert.Equal(t, "third", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+
+	names := processor.spanNames()
+	require.Len(t, names, 3)
+	assert.Equal(t, "first", names[0].name)
+	assert.Equal(t, spanNameStats{
+		name:    "third",
+		buckets: []int64{0, 0, 0, 1, 0, 0, 1, 0, 0},
+		errors:  1,
+	}, names[2])
+}
+
+func TestViewerHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without viewer", func(t *testing.T) {
+		StarCustomTracer(noop.Tracer{})
+
+		rec := httptest.NewRecorder()
+		ViewerHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/traces", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	cfg, err := config.ParseMonitoringConfig(config.WithEnvironment(map[string]string{
+		"OTEL_SERVICE_NAME":        "test-service",
+		"OTEL_TRACES_EXPORTER":     "none",
+		"OTEL_TRACES_VIEWER_SPANS": "10",
+		"OTEL_TRACES_SCRUB_RULES":  "redact:key=user.email",
+	}))
+	require.NoError(t, err)
+	require.NoError(t, StartDefaultTracer(ctx, WithConfig(cfg)))
+	defer func() {
+		require.NoError(t, ShutdownTracerProvider(ctx))
+		StarCustomTracer(noop.Tracer{})
+	}()
+
+	tracer := otel.Tracer("test")
+	rootCtx, root := tracer.Start(ctx, "GET /orders")
+	_, child := tracer.Start(rootCtx, "<script>query</script>")
+	child.SetAttributes(attribute.String("user.email", "jane@example.com"), attribute.Int("rows", 3))
+	child.SetStatus(codes.Error, "timeout")
+	child.End()
+	root.End()
+	traceID := root.SpanContext().TraceID().String()
+
+	_, other := tracer.Start(ctx, "GET /health")
+	other.End()
+
+	t.Run("lists the span names and the recent traces", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		ViewerHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/traces", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, "GET /orders")
+		assert.Contains(t, body, "GET /health")
+		assert.Contains(t, body, `<tr class="error"><td><a href="?trace=`+traceID+`">`)
+		assert.NotContains(t, body, "<script>")
+	})
+
+	t.Run("filters the traces with errors", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		ViewerHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/traces?errors=true", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, traceID)
+		assert.Equal(t, 1, strings.Count(body, `<a href="?trace=`))
+	})
+
+	t.Run("renders the tree of a trace", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		ViewerHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/traces?trace="+traceID, nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, `<td style="padding-left: 8px">GET /orders</td>`)
+		assert.Contains(t, body, `<td style="padding-left: 28px">&lt;script&gt;query&lt;/script&gt;</td>`)
+		assert.Contains(t, body, "Error: timeout")
+		assert.Contains(t, body, "user.email=[REDACTED]")
+		assert.Contains(t, body, "rows=3")
+		assert.NotContains(t, body, "GET /health")
+	})
+
+	t.Run("with invalid trace id", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		ViewerHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/traces?trace=abc", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,324 @@
+// MIT License
+//
+// Copyright (c) 2025 FLYR, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tracer // import "github.com/FLYR-Open-Source/flyr-lib-go/monitoring/tracer"
+
+import (
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// viewerIndent is the indentation in pixels of a child span in the trace tree.
+const viewerIndent = 20
+
+// viewerTemplate renders the latency of the span names and the recent traces, or the tree of a trace.
+var viewerTemplate = template.Must(template.New("viewer").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Traces</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { padding: 4px 8px; border-bottom: 1px solid #ddd; text-align: left; vertical-align: top; }
+.error { background: #fdd; }
+.attributes { color: #555; font-family: monospace; font-size: 12px; }
+</style>
+</head>
+<body>
+{{- if .TraceID}}
+<p><a href="?">All traces</a></p>
+<h1>Trace {{.TraceID}}</h1>
+{{- if .Spans}}
+<table>
+<tr><th>Span</th><th>Kind</th><th>Start</th><th>Duration</th><th>Status</th><th>Attributes</th></tr>
+{{- range .Spans}}
+<tr{{if .Error}} class="error"{{end}}>
+<td style="padding-left: {{.Indent}}px">{{.Name}}</td><td>{{.Kind}}</td><td>+{{.Offset}}</td><td>{{.Duration}}</td><td>{{.Status}}</td>
+<td class="attributes">{{range .Attributes}}{{.}}<br>{{end}}{{range .Events}}event: {{.}}<br>{{end}}</td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>The spans of the trace are not in memory anymore.</p>
+{{- end}}
+{{- else}}
+<h1>Span Names</h1>
+<table>
+<tr><th>Name</th>{{range .Buckets}}<th>{{.}}</th>{{end}}<th>Errors</th></tr>
+{{- range .Names}}
+<tr><td><a href="?name={{.Name}}">{{.Name}}</a></td>{{range .Buckets}}<td>{{.}}</td>{{end}}<td>{{if .Errors}}<a href="?name={{.Name}}&amp;errors=true">{{.Errors}}</a>{{else}}0{{end}}</td></tr>
+{{- end}}
+</table>
+<h1>Recent Traces{{if .Name}} with {{.Name}}{{end}}{{if .Errors}} with errors{{end}}</h1>
+{{- if or .Name .Errors}}
+<p><a href="?">All traces</a></p>
+{{- end}}
+<table>
+<tr><th>Trace</th><th>Root</th><th>Start</th><th>Duration</th><th>Spans</th></tr>
+{{- range .Traces}}
+<tr{{if .Error}} class="error"{{end}}><td><a href="?trace={{.ID}}">{{.ID}}</a></td><td>{{.Name}}</td><td>{{.Start}}</td><td>{{.Duration}}</td><td>{{.Spans}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// viewerPage is the data of the viewer template.
+type viewerPage struct {
+	TraceID string
+	Spans   []viewerSpan
+	Name    string
+	Errors  bool
+	Buckets []string
+	Names   []viewerSpanName
+	Traces  []viewerTrace
+}
+
+// viewerSpanName is a row of the latency of the span names.
+type viewerSpanName struct {
+	Name    string
+	Buckets []int64
+	Errors  int64
+}
+
+// viewerTrace is a row of the recent traces.
+type viewerTrace struct {
+	ID       string
+	Name     string
+	Start    string
+	Duration string
+	Spans    int
+	Error    bool
+	start    time.Time
+}
+
+// viewerSpan is a row of the trace tree.
+type viewerSpan struct {
+	Indent     int
+	Name       string
+	Kind       string
+	Offset     string
+	Duration   string
+	Status     string
+	Error      bool
+	Attributes []string
+	Events     []string
+}
+
+// ViewerHandler returns an http.Handler which renders the spans kept in memory when `OTEL_TRACES_VIEWER_SPANS` is set:
+// the number of spans per name and latency, the recent traces, and the tree of a trace with its durations and attributes.
+// The failed spans and traces are highlighted.
+//
+// It renders the spans of the default tracer (see StartDefaultTracer), and it responds with 404 Not Found if the viewer
+// is not enabled. The spans are scrubbed with `OTEL_TRACES_SCRUB_RULES`, but they can contain any data of the service,
+// so the handler should not be exposed publicly.
+func ViewerHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v *viewerProcessor
+		if tp := defaultTracer.provider; tp != nil {
+			v = tp.viewer
+		}
+		if v == nil {
+			http.Error(w, "the trace viewer is not enabled, see OTEL_TRACES_VIEWER_SPANS", http.StatusNotFound)
+			return
+		}
+
+		query := r.URL.Query()
+		page := viewerPage{
+			TraceID: query.Get("trace"),
+			Name:    query.Get("name"),
+			Errors:  query.Get("errors") == "true",
+		}
+
+		spans := v.recentSpans()
+		if page.TraceID != "" {
+			id, err := trace.TraceIDFromHex(page.TraceID)
+			if err != nil {
+				http.Error(w, "invalid trace id", http.StatusBadRequest)
+				return
+			}
+			page.Spans = traceTree(id, spans)
+		} else {
+			page.Buckets = bucketLabels()
+			for _, stats := range v.spanNames() {
+				page.Names = append(page.Names, viewerSpanName{Name: stats.name, Buckets: stats.buckets, Errors: stats.errors})
+			}
+			page.Traces = recentTraces(spans, page.Name, page.Errors)
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := viewerTemplate.Execute(w, page); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// bucketLabels returns the labels of the latency buckets.
+func bucketLabels() []string {
+	labels := make([]string, 0, len(latencyBounds)+1)
+	for _, bound := range latencyBounds {
+		labels = append(labels, "<"+bound.String())
+	}
+	return append(labels, ">="+latencyBounds[len(latencyBounds)-1].String())
+}
+
+// recentTraces groups the spans by trace, from the newest to the oldest trace.
+// If a name is given, only the traces with a span of that name are returned,
+// and if onlyErrors is true, only the traces with a failed span.
+func recentTraces(spans []sdktrace.ReadOnlySpan, name string, onlyErrors bool) []viewerTrace {
+	byTrace := map[trace.TraceID][]sdktrace.ReadOnlySpan{}
+	for _, s := range spans {
+		id := s.SpanContext().TraceID()
+		byTrace[id] = append(byTrace[id], s)
+	}
+
+	traces := make([]viewerTrace, 0, len(byTrace))
+	for id, traceSpans := range byTrace {
+		t := viewerTrace{ID: id.String(), Spans: len(traceSpans)}
+		hasName := name == ""
+		var end time.Time
+		for _, s := range traceSpans {
+			if t.start.IsZero() || s.StartTime().Before(t.start) {
+				t.start = s.StartTime()
+			}
+			if s.EndTime().After(end) {
+				end = s.EndTime()
+			}
+			t.Error = t.Error || s.Status().Code == codes.Error
+			hasName = hasName || s.Name() == name
+		}
+		if !hasName || (onlyErrors && !t.Error) {
+			continue
+		}
+
+		roots := rootSpans(traceSpans)
+		t.Name = roots[0].Name()
+		t.Start = t.start.Format("2006-01-02 15:04:05.000")
+		t.Duration = formatDuration(end.Sub(t.start))
+		traces = append(traces, t)
+	}
+
+	sort.Slice(traces, func(i, j int) bool {
+		return traces[i].start.After(traces[j].start)
+	})
+	return traces
+}
+
+// traceTree returns the spans of the trace in depth-first order, the children sorted by start time.
+func traceTree(id trace.TraceID, spans []sdktrace.ReadOnlySpan) []viewerSpan {
+	var traceSpans []sdktrace.ReadOnlySpan
+	for _, s := range spans {
+		if s.SpanContext().TraceID() == id {
+			traceSpans = append(traceSpans, s)
+		}
+	}
+	if len(traceSpans) == 0 {
+		return nil
+	}
+
+	children := map[trace.SpanID][]sdktrace.ReadOnlySpan{}
+	for _, s := range traceSpans {
+		children[s.Parent().SpanID()] = append(children[s.Parent().SpanID()], s)
+	}
+
+	roots := rootSpans(traceSpans)
+	start := roots[0].StartTime()
+	for _, root := range roots {
+		if root.StartTime().Before(start) {
+			start = root.StartTime()
+		}
+	}
+
+	var rows []viewerSpan
+	var visit func(s sdktrace.ReadOnlySpan, depth int)
+	visit = func(s sdktrace.ReadOnlySpan, depth int) {
+		row := viewerSpan{
+			Indent:   8 + depth*viewerIndent,
+			Name:     s.Name(),
+			Kind:     s.SpanKind().String(),
+			Offset:   formatDuration(s.StartTime().Sub(start)),
+			Duration: formatDuration(s.EndTime().Sub(s.StartTime())),
+			Status:   s.Status().Code.String(),
+			Error:    s.Status().Code == codes.Error,
+		}
+		if s.Status().Description != "" {
+			row.Status += ": " + s.Status().Description
+		}
+		for _, attr := range s.Attributes() {
+			row.Attributes = append(row.Attributes, string(attr.Key)+"="+attr.Value.Emit())
+		}
+		for _, event := range s.Events() {
+			row.Events = append(row.Events, "+"+formatDuration(event.Time.Sub(start))+" "+event.Name)
+		}
+		rows = append(rows, row)
+
+		next := children[s.SpanContext().SpanID()]
+		sortByStart(next)
+		for _, child := range next {
+			visit(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		visit(root, 0)
+	}
+
+	return rows
+}
+
+// rootSpans returns the spans of a trace whose parent is not among the spans, sorted by start time.
+func rootSpans(spans []sdktrace.ReadOnlySpan) []sdktrace.ReadOnlySpan {
+	ids := make(map[trace.SpanID]bool, len(spans))
+	for _, s := range spans {
+		ids[s.SpanContext().SpanID()] = true
+	}
+
+	var roots []sdktrace.ReadOnlySpan
+	for _, s := range spans {
+		if !ids[s.Parent().SpanID()] {
+			roots = append(roots, s)
+		}
+	}
+	sortByStart(roots)
+
+	return roots
+}
+
+// sortByStart sorts the spans by start time.
+func sortByStart(spans []sdktrace.ReadOnlySpan) {
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].StartTime().Before(spans[j].StartTime())
+	})
+}
+
+// formatDuration formats the duration in milliseconds, with microsecond precision.
+func formatDuration(d time.Duration) string {
+	return strconv.FormatFloat(float64(d.Microseconds())/1000, 'f', 3, 64) + "ms"
+}